@@ -0,0 +1,100 @@
+package clock_test
+
+import (
+	"."
+	"../reckon"
+	"../suiteshop"
+
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test(t *testing.T) {
+	list := []string{}
+	start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	hasErrors := suiteshop.Describe("Clock", func(suite *suiteshop.Suite) {
+		suite.Test("Real", func(log *suiteshop.Log) {
+			c := clock.Real()
+			before := c.Now()
+			c.Sleep(time.Millisecond)
+			reckon.That(c.Since(before) >= time.Millisecond).Is.True()
+			<-c.After(time.Millisecond)
+			timer := c.NewTimer(time.Hour)
+			reckon.That(timer.Stop()).Is.True()
+		})
+		suite.Describe("Fake", func(suite *suiteshop.Suite) {
+			suite.Test("Advance & Set", func(log *suiteshop.Log) {
+				c := clock.NewFake(start)
+				reckon.That(c.Now()).Is.EqualTo(start)
+				c.Advance(time.Minute)
+				reckon.That(c.Since(start)).Is.EqualTo(time.Minute)
+				c.Set(start.Add(time.Hour))
+				reckon.That(c.Now()).Is.EqualTo(start.Add(time.Hour))
+			})
+			suite.Test("Timer", func(log *suiteshop.Log) {
+				c := clock.NewFake(start)
+				timer := c.NewTimer(time.Second)
+				c.Advance(999 * time.Millisecond)
+				select {
+				case <-timer.C():
+					panic("fired early")
+				default:
+				}
+				c.Advance(time.Millisecond)
+				reckon.That(<-timer.C()).Is.EqualTo(start.Add(time.Second))
+				reckon.That(timer.Stop()).Is.False()
+				reckon.That(timer.Reset(time.Second)).Is.False()
+				reckon.That(timer.Stop()).Is.True()
+				c.Advance(time.Hour)
+				reckon.That(c.Waiters()).Is.EqualTo(0)
+			})
+			suite.Test("Ticker", func(log *suiteshop.Log) {
+				c := clock.NewFake(start)
+				ticker := c.NewTicker(time.Second)
+				c.Advance(time.Second)
+				reckon.That(<-ticker.C()).Is.EqualTo(start.Add(time.Second))
+				c.Advance(time.Second)
+				reckon.That(<-ticker.C()).Is.EqualTo(start.Add(2 * time.Second))
+				ticker.Stop()
+				c.Advance(time.Second)
+				select {
+				case <-ticker.C():
+					panic("stopped ticker fired")
+				default:
+				}
+				reckon.That(func() {
+					c.NewTicker(0)
+				}).Will.Panic()
+			})
+			suite.Test("Sleep", func(log *suiteshop.Log) {
+				c := clock.NewFake(start)
+				done := make(chan time.Time)
+				go func() {
+					c.Sleep(time.Minute)
+					done <- c.Now()
+				}()
+				c.BlockUntil(1)
+				c.Advance(time.Minute)
+				reckon.That(<-done).Is.EqualTo(start.Add(time.Minute))
+				<-c.After(0)
+			})
+			suite.Test("AutoAdvance", func(log *suiteshop.Log) {
+				c := clock.NewFake(start).AutoAdvance()
+				timer := c.NewTimer(time.Second)
+				c.Sleep(time.Minute)
+				reckon.That(c.Now()).Is.EqualTo(start.Add(time.Minute))
+				reckon.That(<-timer.C()).Is.EqualTo(start.Add(time.Second))
+				reckon.That(c.Waiters()).Is.EqualTo(0)
+			})
+		})
+	}).Post(func(message string) {
+		list = append(list, message)
+	})
+	if hasErrors {
+		t.Fatal(strings.Join(list, "\n"))
+	} else {
+		fmt.Println(strings.Join(list, "\n"))
+	}
+}
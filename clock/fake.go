@@ -0,0 +1,183 @@
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+type Fake struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []*waiter
+	changed chan struct{}
+	auto    bool
+}
+
+func NewFake(start time.Time) *Fake {
+	return &Fake{now: start, changed: make(chan struct{})}
+}
+
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *Fake) Since(t time.Time) time.Duration {
+	return f.Now().Sub(t)
+}
+
+func (f *Fake) Sleep(d time.Duration) {
+	f.mu.Lock()
+	auto := f.auto
+	f.mu.Unlock()
+	if auto {
+		f.Advance(d)
+		return
+	}
+	<-f.After(d)
+}
+
+func (f *Fake) AutoAdvance() *Fake {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.auto = true
+	return f
+}
+
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	return f.NewTimer(d).C()
+}
+
+func (f *Fake) NewTimer(d time.Duration) Timer {
+	w := &waiter{fake: f, c: make(chan time.Time, 1)}
+	w.Reset(d)
+	return w
+}
+
+func (f *Fake) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+	w := &waiter{fake: f, c: make(chan time.Time, 1), period: d}
+	w.Reset(d)
+	return &ticker{w}
+}
+
+func (f *Fake) Advance(d time.Duration) {
+	f.Set(f.Now().Add(d))
+}
+
+func (f *Fake) Set(t time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = t
+	f.fire()
+}
+
+func (f *Fake) Waiters() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.waiters)
+}
+
+func (f *Fake) BlockUntil(count int) {
+	for {
+		f.mu.Lock()
+		if len(f.waiters) >= count {
+			f.mu.Unlock()
+			return
+		}
+		changed := f.changed
+		f.mu.Unlock()
+		<-changed
+	}
+}
+
+func (f *Fake) fire() {
+	for {
+		sort.SliceStable(f.waiters, func(i, j int) bool {
+			return f.waiters[i].at.Before(f.waiters[j].at)
+		})
+		if len(f.waiters) == 0 || f.waiters[0].at.After(f.now) {
+			return
+		}
+		w := f.waiters[0]
+		select {
+		case w.c <- w.at:
+		default:
+		}
+		if w.period > 0 {
+			w.at = w.at.Add(w.period)
+		} else {
+			f.remove(w)
+		}
+	}
+}
+
+func (f *Fake) add(w *waiter) {
+	f.waiters = append(f.waiters, w)
+	f.notify()
+	f.fire()
+}
+
+func (f *Fake) remove(w *waiter) bool {
+	for index, item := range f.waiters {
+		if item == w {
+			f.waiters = append(f.waiters[:index], f.waiters[index+1:]...)
+			f.notify()
+			return true
+		}
+	}
+	return false
+}
+
+func (f *Fake) notify() {
+	close(f.changed)
+	f.changed = make(chan struct{})
+}
+
+type waiter struct {
+	fake   *Fake
+	at     time.Time
+	period time.Duration
+	c      chan time.Time
+}
+
+func (w *waiter) C() <-chan time.Time {
+	return w.c
+}
+
+func (w *waiter) Stop() bool {
+	w.fake.mu.Lock()
+	defer w.fake.mu.Unlock()
+	return w.fake.remove(w)
+}
+
+func (w *waiter) Reset(d time.Duration) bool {
+	w.fake.mu.Lock()
+	defer w.fake.mu.Unlock()
+	active := w.fake.remove(w)
+	if w.period > 0 {
+		w.period = d
+	}
+	w.at = w.fake.now.Add(d)
+	w.fake.add(w)
+	return active
+}
+
+type ticker struct {
+	*waiter
+}
+
+func (t *ticker) Stop() {
+	t.waiter.Stop()
+}
+
+func (t *ticker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("non-positive interval for Ticker.Reset")
+	}
+	t.waiter.Reset(d)
+}
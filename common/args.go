@@ -13,8 +13,18 @@ func Any() interface{} {
 
 var any interface{} = struct{}{}
 
+var zeros interface{} = struct{ zeros bool }{true}
+
 type Args []interface{}
 
+func ZeroArgs() *Args {
+	return &Args{zeros}
+}
+
+func (a *Args) zeroed() bool {
+	return len(*a) == 1 && (*a)[0] == zeros
+}
+
 func (a *Args) PopLast() *Arg {
 	size := len(*a)
 	if size == 0 {
@@ -41,12 +51,16 @@ func (a *Args) Add(value reflect.Value) {
 }
 
 func (a *Args) Matches(args *Args) bool {
+	return a.MatchesWith(args, reflect.DeepEqual)
+}
+
+func (a *Args) MatchesWith(args *Args, equal func(expected, actual interface{}) bool) bool {
 	if len(*args) > len(*a) {
 		return false
 	}
 	count := len(*args)
 	for x := 0; x < count; x++ {
-		if !reflect.DeepEqual((*a)[x], (*args)[x]) && !reflect.DeepEqual((*a)[x], any) {
+		if !matchesItem((*a)[x], (*args)[x], equal) {
 			return false
 		}
 	}
@@ -96,22 +110,29 @@ func (a *Args) Each(fn func(item *Arg, index int)) {
 }
 
 func (a *Args) Len() int {
+	if a.zeroed() {
+		return 0
+	}
 	return len(*a)
 }
 
 func (a *Args) Get(index int) *Arg {
+	if a.zeroed() {
+		return &Arg{nil, true}
+	}
 	if len(*a) <= index {
 		return &Arg{}
 	}
-	return &Arg{(*a)[index]}
+	return &Arg{(*a)[index], false}
 }
 
 type Arg struct {
 	inner interface{}
+	zero  bool
 }
 
 func NewArg(elem interface{}) *Arg {
-	return &Arg{elem}
+	return &Arg{elem, false}
 }
 
 func (a *Arg) Elem() interface{} {
@@ -127,10 +148,16 @@ func (a *Arg) ValueOf() reflect.Value {
 }
 
 func (a *Arg) Bool() bool {
+	if a.zero {
+		return false
+	}
 	return a.ValueOf().Bool()
 }
 
 func (a *Arg) String() string {
+	if a.zero {
+		return ""
+	}
 	return fmt.Sprintf("%v", a.inner)
 }
 
@@ -147,53 +174,60 @@ func (a *Arg) Bytes() []byte {
 }
 
 func (a *Arg) Byte() byte {
-	return tribble.NewTribble(a.inner).Byte()
+	return tribble.NewTribble(a.number()).Byte()
 }
 
 func (a *Arg) Float32() float32 {
-	return tribble.NewTribble(a.inner).Float32()
+	return tribble.NewTribble(a.number()).Float32()
 }
 
 func (a *Arg) Float64() float64 {
-	return tribble.NewTribble(a.inner).Float64()
+	return tribble.NewTribble(a.number()).Float64()
 }
 
 func (a *Arg) Int() int {
-	return tribble.NewTribble(a.inner).Int()
+	return tribble.NewTribble(a.number()).Int()
 }
 
 func (a *Arg) Int8() int8 {
-	return tribble.NewTribble(a.inner).Int8()
+	return tribble.NewTribble(a.number()).Int8()
 }
 
 func (a *Arg) Int16() int16 {
-	return tribble.NewTribble(a.inner).Int16()
+	return tribble.NewTribble(a.number()).Int16()
 }
 
 func (a *Arg) Int32() int32 {
-	return tribble.NewTribble(a.inner).Int32()
+	return tribble.NewTribble(a.number()).Int32()
 }
 
 func (a *Arg) Int64() int64 {
-	return tribble.NewTribble(a.inner).Int64()
+	return tribble.NewTribble(a.number()).Int64()
 }
 
 func (a *Arg) UInt() uint {
-	return tribble.NewTribble(a.inner).UInt()
+	return tribble.NewTribble(a.number()).UInt()
 }
 
 func (a *Arg) UInt16() uint16 {
-	return tribble.NewTribble(a.inner).UInt16()
+	return tribble.NewTribble(a.number()).UInt16()
 }
 
 func (a *Arg) UInt32() uint32 {
-	return tribble.NewTribble(a.inner).UInt32()
+	return tribble.NewTribble(a.number()).UInt32()
 }
 
 func (a *Arg) UInt64() uint64 {
-	return tribble.NewTribble(a.inner).UInt64()
+	return tribble.NewTribble(a.number()).UInt64()
 }
 
 func (a *Arg) UIntPtr() uintptr {
-	return tribble.NewTribble(a.inner).UIntPtr()
+	return tribble.NewTribble(a.number()).UIntPtr()
+}
+
+func (a *Arg) number() interface{} {
+	if a.zero {
+		return 0
+	}
+	return a.inner
 }
@@ -4,11 +4,14 @@ import (
 	"."
 	"../reckon"
 	"../suiteshop"
+	"context"
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 func Test(t *testing.T) {
@@ -81,6 +84,78 @@ func Test(t *testing.T) {
 				reckon.That(args5.Matches(args1)).Is.False()
 			})
 		})
+		suite.Describe("Matchers", func(suite *suiteshop.Suite) {
+			suite.Test("Regex & Range", func(log *suiteshop.Log) {
+				args := &common.Args{common.Regex("^a+$"), common.Range(1, 3)}
+				reckon.That(args.Matches(&common.Args{"aaa", 2})).Is.True()
+				reckon.That(args.Matches(&common.Args{[]byte("aa"), 3.0})).Is.True()
+				reckon.That(args.Matches(&common.Args{"ab", 2})).Is.False()
+				reckon.That(args.Matches(&common.Args{"a", 4})).Is.False()
+				reckon.That(args.Matches(&common.Args{"a", "2"})).Is.False()
+			})
+			suite.Test("Context", func(log *suiteshop.Log) {
+				type key string
+				ctx := context.WithValue(context.Background(), key("user"), "bob")
+				deadline, cancel := context.WithTimeout(ctx, time.Hour)
+				reckon.That((&common.Args{context.Background(), 1}).Matches(&common.Args{ctx, 1})).Is.True()
+				reckon.That((&common.Args{context.TODO(), 1}).Matches(&common.Args{"ctx", 1})).Is.False()
+				reckon.That((&common.Args{common.CtxHasValue(key("user"), "bob")}).Matches(&common.Args{ctx})).Is.True()
+				reckon.That((&common.Args{common.CtxHasValue(key("user"), common.Any())}).Matches(&common.Args{ctx})).Is.True()
+				reckon.That((&common.Args{common.CtxHasValue(key("user"), "ann")}).Matches(&common.Args{ctx})).Is.False()
+				reckon.That((&common.Args{common.CtxHasDeadline()}).Matches(&common.Args{deadline})).Is.True()
+				reckon.That((&common.Args{common.CtxHasDeadline()}).Matches(&common.Args{ctx})).Is.False()
+				reckon.That((&common.Args{common.CtxCancelled()}).Matches(&common.Args{deadline})).Is.False()
+				cancel()
+				reckon.That((&common.Args{common.CtxCancelled()}).Matches(&common.Args{deadline})).Is.True()
+			})
+		})
+		suite.Describe("Structural", func(suite *suiteshop.Suite) {
+			type user struct {
+				ID      int
+				Tags    []string
+				Created time.Time
+				Score   float64
+				secret  string
+				Friend  *user
+			}
+			suite.Test("pointers", func(log *suiteshop.Log) {
+				compare := common.Structural()
+				reckon.That(compare.Equal(&user{ID: 1}, &user{ID: 1})).Is.True()
+				reckon.That(compare.Equal(&user{ID: 1}, user{ID: 1})).Is.True()
+				reckon.That(compare.Equal(&user{ID: 1}, &user{ID: 2})).Is.False()
+				reckon.That(compare.Equal((*user)(nil), nil)).Is.True()
+				first := &user{ID: 1}
+				second := &user{ID: 1}
+				first.Friend = first
+				second.Friend = second
+				reckon.That(compare.Equal(first, second)).Is.True()
+			})
+			suite.Test("options", func(log *suiteshop.Log) {
+				now := time.Now()
+				expected := user{ID: 1, Created: now, secret: "a"}
+				actual := user{ID: 1, Created: now.Add(time.Hour), Tags: []string{}, secret: "b"}
+				reckon.That(common.Structural().Equal(expected, actual)).Is.False()
+				compare := common.Structural().IgnoreUnexported().IgnoreFields("Created").NilEqualsEmpty()
+				reckon.That(compare.Equal(expected, actual)).Is.True()
+				reckon.That(common.Structural().IgnoreUnexported().IgnoreFields("user.Created").NilEqualsEmpty().Equal(expected, actual)).Is.True()
+				reckon.That(compare.Equal(map[string]int(nil), map[string]int{})).Is.True()
+				reckon.That(common.Structural().Equal([]int(nil), []int{})).Is.False()
+			})
+			suite.Test("time & NaN", func(log *suiteshop.Log) {
+				now := time.Now()
+				reckon.That(common.Structural().Equal(now, now.In(time.UTC))).Is.True()
+				reckon.That(common.Structural().Equal(user{Score: math.NaN()}, user{Score: math.NaN()})).Is.True()
+				reckon.That(common.Structural().Equal(math.NaN(), 1.0)).Is.False()
+			})
+			suite.Test("matching", func(log *suiteshop.Log) {
+				compare := common.Structural().IgnoreFields("Created")
+				args := &common.Args{compare.Like(&user{ID: 1})}
+				reckon.That(args.Matches(&common.Args{&user{ID: 1, Created: time.Now()}})).Is.True()
+				args = &common.Args{&user{ID: 1}}
+				reckon.That(args.MatchesWith(&common.Args{&user{ID: 1, Created: time.Now()}}, compare.Equal)).Is.True()
+				reckon.That(args.Matches(&common.Args{&user{ID: 1, Created: time.Now()}})).Is.False()
+			})
+		})
 		suite.Describe("Casting", func(suite *suiteshop.Suite) {
 			suite.Test("Numbers", func(log *suiteshop.Log) {
 				args := &common.Args{-71215.23546873}
@@ -162,6 +237,16 @@ func Test(t *testing.T) {
 					arg.Bool()
 				}).Will.Panic()
 			})
+			suite.Test("Zero", func(log *suiteshop.Log) {
+				args := common.ZeroArgs()
+				reckon.That(args.Len()).Is.EqualTo(0)
+				reckon.That(args.Get(0).Bool()).Is.False()
+				reckon.That(args.Get(1).Int()).Is.EqualTo(0)
+				reckon.That(args.Get(2).Float64()).Is.EqualTo(0.0)
+				reckon.That(args.Get(3).String()).Is.EqualTo("")
+				reckon.That(args.Get(4).Error()).Is.Nil()
+				reckon.That(args.Get(5).Elem()).Is.Nil()
+			})
 		})
 		suite.Test("Some", func(log *suiteshop.Log) {
 			args := &common.Args{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
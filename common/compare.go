@@ -0,0 +1,190 @@
+package common
+
+import (
+	"math"
+	"reflect"
+)
+
+type Comparison struct {
+	ignoreUnexported bool
+	nilEqualsEmpty   bool
+	ignoreFields     map[string]bool
+}
+
+func Structural() *Comparison {
+	return &Comparison{ignoreFields: map[string]bool{}}
+}
+
+func (c *Comparison) IgnoreUnexported() *Comparison {
+	c.ignoreUnexported = true
+	return c
+}
+
+func (c *Comparison) IgnoreFields(names ...string) *Comparison {
+	for _, name := range names {
+		c.ignoreFields[name] = true
+	}
+	return c
+}
+
+func (c *Comparison) NilEqualsEmpty() *Comparison {
+	c.nilEqualsEmpty = true
+	return c
+}
+
+func (c *Comparison) Like(expected interface{}) Matcher {
+	return MatcherFunc(func(actual interface{}) bool {
+		return c.Equal(expected, actual)
+	})
+}
+
+func (c *Comparison) Equal(expected, actual interface{}) bool {
+	return c.equal(reflect.ValueOf(expected), reflect.ValueOf(actual), map[visit]bool{})
+}
+
+type visit struct {
+	expected uintptr
+	actual   uintptr
+	t        reflect.Type
+}
+
+func (c *Comparison) equal(expected, actual reflect.Value, visited map[visit]bool) bool {
+	if expected.Kind() == reflect.Ptr && actual.Kind() == reflect.Ptr && expected.Type() == actual.Type() {
+		if !expected.IsNil() && !actual.IsNil() && c.seen(expected, actual, visited) {
+			return true
+		}
+	}
+	expected = c.deref(expected)
+	actual = c.deref(actual)
+	if !expected.IsValid() || !actual.IsValid() {
+		return c.emptyOrInvalid(expected) && c.emptyOrInvalid(actual)
+	}
+	if expected.Type() != actual.Type() {
+		return false
+	}
+	if equal, ok := callEqual(expected, actual); ok {
+		return equal
+	}
+	switch expected.Kind() {
+	case reflect.Float32, reflect.Float64:
+		e, a := expected.Float(), actual.Float()
+		return e == a || (math.IsNaN(e) && math.IsNaN(a))
+	case reflect.Complex64, reflect.Complex128:
+		e, a := expected.Complex(), actual.Complex()
+		return e == a || (isNaNComplex(e) && isNaNComplex(a))
+	case reflect.Struct:
+		return c.equalStruct(expected, actual, visited)
+	case reflect.Slice, reflect.Array:
+		if expected.Kind() == reflect.Slice && !c.nilEqualsEmpty && expected.IsNil() != actual.IsNil() {
+			return false
+		}
+		if expected.Len() != actual.Len() {
+			return false
+		}
+		if expected.Kind() == reflect.Slice && c.seen(expected, actual, visited) {
+			return true
+		}
+		for index := 0; index < expected.Len(); index++ {
+			if !c.equal(expected.Index(index), actual.Index(index), visited) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		if !c.nilEqualsEmpty && expected.IsNil() != actual.IsNil() {
+			return false
+		}
+		if expected.Len() != actual.Len() {
+			return false
+		}
+		if c.seen(expected, actual, visited) {
+			return true
+		}
+		for _, key := range expected.MapKeys() {
+			value := actual.MapIndex(key)
+			if !value.IsValid() || !c.equal(expected.MapIndex(key), value, visited) {
+				return false
+			}
+		}
+		return true
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return expected.Pointer() == actual.Pointer()
+	case reflect.Bool:
+		return expected.Bool() == actual.Bool()
+	case reflect.String:
+		return expected.String() == actual.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return expected.Int() == actual.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return expected.Uint() == actual.Uint()
+	}
+	return false
+}
+
+func (c *Comparison) equalStruct(expected, actual reflect.Value, visited map[visit]bool) bool {
+	t := expected.Type()
+	for index := 0; index < t.NumField(); index++ {
+		field := t.Field(index)
+		if c.ignoreUnexported && len(field.PkgPath) > 0 {
+			continue
+		}
+		if c.ignoreFields[field.Name] || c.ignoreFields[t.Name()+"."+field.Name] {
+			continue
+		}
+		if !c.equal(expected.Field(index), actual.Field(index), visited) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Comparison) deref(value reflect.Value) reflect.Value {
+	for value.IsValid() && (value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface) {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		value = value.Elem()
+	}
+	return value
+}
+
+func (c *Comparison) emptyOrInvalid(value reflect.Value) bool {
+	if !value.IsValid() {
+		return true
+	}
+	if !c.nilEqualsEmpty {
+		return false
+	}
+	switch value.Kind() {
+	case reflect.Slice, reflect.Map:
+		return value.Len() == 0
+	}
+	return false
+}
+
+func (c *Comparison) seen(expected, actual reflect.Value, visited map[visit]bool) bool {
+	key := visit{expected.Pointer(), actual.Pointer(), expected.Type()}
+	if visited[key] {
+		return true
+	}
+	visited[key] = true
+	return false
+}
+
+func callEqual(expected, actual reflect.Value) (bool, bool) {
+	if !expected.CanInterface() || !actual.CanInterface() {
+		return false, false
+	}
+	method, ok := expected.Type().MethodByName("Equal")
+	if !ok || method.Type.NumIn() != 2 || method.Type.NumOut() != 1 {
+		return false, false
+	}
+	if method.Type.In(1) != expected.Type() || method.Type.Out(0).Kind() != reflect.Bool {
+		return false, false
+	}
+	return method.Func.Call([]reflect.Value{expected, actual})[0].Bool(), true
+}
+
+func isNaNComplex(value complex128) bool {
+	return math.IsNaN(real(value)) || math.IsNaN(imag(value))
+}
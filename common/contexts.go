@@ -0,0 +1,34 @@
+package common
+
+import (
+	"context"
+	"reflect"
+)
+
+func CtxHasValue(key, value interface{}) Matcher {
+	return MatcherFunc(func(actual interface{}) bool {
+		ctx, ok := actual.(context.Context)
+		if !ok {
+			return false
+		}
+		return matchesItem(value, ctx.Value(key), reflect.DeepEqual)
+	})
+}
+
+func CtxHasDeadline() Matcher {
+	return MatcherFunc(func(actual interface{}) bool {
+		ctx, ok := actual.(context.Context)
+		if !ok {
+			return false
+		}
+		_, has := ctx.Deadline()
+		return has
+	})
+}
+
+func CtxCancelled() Matcher {
+	return MatcherFunc(func(actual interface{}) bool {
+		ctx, ok := actual.(context.Context)
+		return ok && ctx.Err() != nil
+	})
+}
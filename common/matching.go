@@ -0,0 +1,78 @@
+package common
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+)
+
+type Matcher interface {
+	Match(actual interface{}) bool
+}
+
+type MatcherFunc func(actual interface{}) bool
+
+func (m MatcherFunc) Match(actual interface{}) bool {
+	return m(actual)
+}
+
+func Regex(pattern string) Matcher {
+	exp := regexp.MustCompile(pattern)
+	return MatcherFunc(func(actual interface{}) bool {
+		switch value := actual.(type) {
+		case string:
+			return exp.MatchString(value)
+		case []byte:
+			return exp.Match(value)
+		default:
+			return false
+		}
+	})
+}
+
+func Range(low, high interface{}) Matcher {
+	lowValue := toFloat64(reflect.ValueOf(low))
+	highValue := toFloat64(reflect.ValueOf(high))
+	return MatcherFunc(func(actual interface{}) bool {
+		value := reflect.ValueOf(actual)
+		if !isNumber(value) {
+			return false
+		}
+		number := toFloat64(value)
+		return number >= lowValue && number <= highValue
+	})
+}
+
+func matchesItem(expected, actual interface{}, equal func(expected, actual interface{}) bool) bool {
+	if reflect.DeepEqual(expected, any) || equal(expected, actual) {
+		return true
+	}
+	if matcher, ok := expected.(Matcher); ok {
+		return matcher.Match(actual)
+	}
+	_, expectedContext := expected.(context.Context)
+	_, actualContext := actual.(context.Context)
+	return expectedContext && actualContext
+}
+
+func isNumber(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func toFloat64(value reflect.Value) float64 {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(value.Uint())
+	case reflect.Float32, reflect.Float64:
+		return value.Float()
+	}
+	panic("Value is not a number")
+}
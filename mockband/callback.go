@@ -0,0 +1,90 @@
+package mockband
+
+import (
+	"../common"
+	"fmt"
+	"reflect"
+)
+
+func Do(c *call, fn interface{}) *call {
+	callback := reflect.ValueOf(fn)
+	if callback.Kind() != reflect.Func || callback.IsNil() {
+		panic("Callback is not a function")
+	}
+	return c.Then(func(args *common.Args) *common.Args {
+		return invoke(callback, *args)
+	})
+}
+
+func invoke(callback reflect.Value, args common.Args) *common.Args {
+	signature := callback.Type()
+	count := signature.NumIn()
+	spread := signature.IsVariadic() && len(args) == count && args[count-1] != nil &&
+		reflect.TypeOf(args[count-1]).AssignableTo(signature.In(count-1))
+	if signature.IsVariadic() && !spread {
+		if len(args) < count-1 {
+			panic(fmt.Sprintf("Callback expects at least %v parameters, got %v", count-1, len(args)))
+		}
+	} else if len(args) != count {
+		panic(fmt.Sprintf("Callback expects %v parameters, got %v", count, len(args)))
+	}
+	in := make([]reflect.Value, len(args))
+	for index, arg := range args {
+		expected := callbackIn(signature, index, spread)
+		if arg == nil {
+			checkAssignable(nil, expected, fmt.Sprintf("Callback parameter %v", index))
+			in[index] = reflect.Zero(expected)
+			continue
+		}
+		checkAssignable(arg, expected, fmt.Sprintf("Callback parameter %v", index))
+		in[index] = reflect.ValueOf(arg)
+	}
+	var values []reflect.Value
+	if spread {
+		values = callback.CallSlice(in)
+	} else {
+		values = callback.Call(in)
+	}
+	out := common.Args{}
+	for _, value := range values {
+		out = append(out, value.Interface())
+	}
+	return &out
+}
+
+func callbackIn(signature reflect.Type, index int, spread bool) reflect.Type {
+	last := signature.NumIn() - 1
+	if signature.IsVariadic() && !spread && index >= last {
+		return signature.In(last).Elem()
+	}
+	return signature.In(index)
+}
+
+func (t *typedStub[F]) Do(fn interface{}) *typedStub[F] {
+	callback := reflect.ValueOf(fn)
+	if callback.Kind() != reflect.Func || callback.IsNil() {
+		panic("Callback is not a function")
+	}
+	signature := t.method.signature
+	actual := callback.Type()
+	count := signature.NumIn() - t.method.offset
+	if actual.NumIn() != count || actual.IsVariadic() != signature.IsVariadic() {
+		panic(fmt.Sprintf("Callback for %v has %v parameters, expected %v", t.method.name, actual.NumIn(), count))
+	}
+	for index := 0; index < count; index++ {
+		expected := signature.In(t.method.offset + index)
+		if !expected.AssignableTo(actual.In(index)) {
+			panic(fmt.Sprintf("Callback parameter %v of %v: %v is not assignable to %v", index, t.method.name, expected, actual.In(index)))
+		}
+	}
+	if actual.NumOut() != signature.NumOut() {
+		panic(fmt.Sprintf("Wrong number of return values for %v: %v != %v", t.method.name, actual.NumOut(), signature.NumOut()))
+	}
+	for index := 0; index < actual.NumOut(); index++ {
+		if !actual.Out(index).AssignableTo(signature.Out(index)) {
+			panic(fmt.Sprintf("Return value %v of %v: %v is not assignable to %v", index, t.method.name, actual.Out(index), signature.Out(index)))
+		}
+	}
+	Do(t.call, fn)
+	return t
+}
@@ -0,0 +1,215 @@
+package mockband
+
+import (
+	"reflect"
+	"sync"
+	"time"
+)
+
+type Channel[T interface{}] struct {
+	mu         sync.Mutex
+	in         chan T
+	out        chan T
+	sent       []T
+	queue      []T
+	delivered  int
+	closeAfter int
+	blocked    bool
+	closed     bool
+	done       chan struct{}
+	changed    chan struct{}
+}
+
+func Chan[T interface{}](t TB) *Channel[T] {
+	c := &Channel[T]{
+		in:         make(chan T),
+		out:        make(chan T),
+		sent:       []T{},
+		queue:      []T{},
+		closeAfter: -1,
+		done:       make(chan struct{}),
+		changed:    make(chan struct{}),
+	}
+	go c.record()
+	go c.deliver()
+	t.Cleanup(c.Close)
+	return c
+}
+
+func (c *Channel[T]) In() chan<- T {
+	return c.in
+}
+
+func (c *Channel[T]) Out() <-chan T {
+	return c.out
+}
+
+func (c *Channel[T]) Deliver(values ...T) *Channel[T] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.queue = append(c.queue, values...)
+	c.broadcast()
+	return c
+}
+
+func (c *Channel[T]) CloseAfter(n int) *Channel[T] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closeAfter = n
+	c.broadcast()
+	return c
+}
+
+func (c *Channel[T]) Block() *Channel[T] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.blocked = true
+	c.broadcast()
+	return c
+}
+
+func (c *Channel[T]) Unblock() *Channel[T] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.blocked = false
+	c.broadcast()
+	return c
+}
+
+func (c *Channel[T]) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	select {
+	case <-c.done:
+	default:
+		close(c.done)
+	}
+}
+
+func (c *Channel[T]) Sent() []T {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]T{}, c.sent...)
+}
+
+func (c *Channel[T]) Delivered() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.delivered
+}
+
+func (c *Channel[T]) Closed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+func (c *Channel[T]) SentExactly(within time.Duration, values ...T) bool {
+	return c.waitFor(within, func() (bool, bool) {
+		return c.sentEqual(values), len(values) > 0 && len(c.sent) >= len(values)
+	})
+}
+
+func (c *Channel[T]) WaitClosed(within time.Duration) bool {
+	return c.waitFor(within, func() (bool, bool) {
+		return c.closed, c.closed
+	})
+}
+
+func (c *Channel[T]) waitFor(within time.Duration, check func() (bool, bool)) bool {
+	timer := time.NewTimer(within)
+	defer timer.Stop()
+	for {
+		c.mu.Lock()
+		result, final := check()
+		changed := c.changed
+		c.mu.Unlock()
+		if final {
+			return result
+		}
+		select {
+		case <-changed:
+		case <-timer.C:
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			result, _ = check()
+			return result
+		}
+	}
+}
+
+func (c *Channel[T]) sentEqual(values []T) bool {
+	if len(c.sent) != len(values) {
+		return false
+	}
+	for index, value := range values {
+		if !reflect.DeepEqual(c.sent[index], value) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Channel[T]) broadcast() {
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
+
+func (c *Channel[T]) record() {
+	for {
+		c.mu.Lock()
+		changed := c.changed
+		var in chan T
+		if !c.blocked {
+			in = c.in
+		}
+		c.mu.Unlock()
+		select {
+		case value, ok := <-in:
+			c.mu.Lock()
+			if ok {
+				c.sent = append(c.sent, value)
+			} else {
+				c.closed = true
+			}
+			c.broadcast()
+			c.mu.Unlock()
+			if !ok {
+				return
+			}
+		case <-changed:
+		case <-c.done:
+			return
+		}
+	}
+}
+
+func (c *Channel[T]) deliver() {
+	defer close(c.out)
+	for {
+		c.mu.Lock()
+		if c.closeAfter >= 0 && c.delivered >= c.closeAfter {
+			c.mu.Unlock()
+			return
+		}
+		changed := c.changed
+		var out chan T
+		var value T
+		if !c.blocked && len(c.queue) > 0 {
+			out = c.out
+			value = c.queue[0]
+		}
+		c.mu.Unlock()
+		select {
+		case out <- value:
+			c.mu.Lock()
+			c.queue = c.queue[1:]
+			c.delivered++
+			c.broadcast()
+			c.mu.Unlock()
+		case <-changed:
+		case <-c.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package mockband
+
+import (
+	"context"
+	"time"
+)
+
+type ContextInfo struct {
+	Deadline    time.Time
+	HasDeadline bool
+	Err         error
+	Values      map[interface{}]interface{}
+}
+
+func (m *Mock) RecordContextKeys(keys ...interface{}) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.contextKeys = append(m.contextKeys, keys...)
+	return m
+}
+
+func (m *Mock) captureContext(params []interface{}) *ContextInfo {
+	for _, param := range params {
+		ctx, ok := param.(context.Context)
+		if !ok {
+			continue
+		}
+		info := &ContextInfo{Err: ctx.Err(), Values: map[interface{}]interface{}{}}
+		info.Deadline, info.HasDeadline = ctx.Deadline()
+		for _, key := range m.contextKeys {
+			if value := ctx.Value(key); value != nil {
+				info.Values[key] = value
+			}
+		}
+		return info
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package mockband
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type mockRegistry struct {
+	mu    sync.Mutex
+	mocks []*Mock
+}
+
+var registry = &mockRegistry{mocks: []*Mock{}}
+
+func (r *mockRegistry) add(m *Mock) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, item := range r.mocks {
+		if item == m {
+			return
+		}
+	}
+	r.mocks = append(r.mocks, m)
+}
+
+func (r *mockRegistry) list() []*Mock {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*Mock{}, r.mocks...)
+}
+
+func (r *mockRegistry) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.mocks = []*Mock{}
+}
+
+func (m *Mock) Named(name string) *Mock {
+	m.mu.Lock()
+	m.name = name
+	m.mu.Unlock()
+	registry.add(m)
+	return m
+}
+
+func (m *Mock) Implements(iface interface{}) *Mock {
+	t := reflect.TypeOf(iface)
+	if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Interface {
+		panic("Implements requires a nil interface pointer, e.g. (*Iface)(nil)")
+	}
+	m.mu.Lock()
+	m.iface = t.Elem()
+	m.mu.Unlock()
+	registry.add(m)
+	return m
+}
+
+func (m *Mock) label() string {
+	if m.iface != nil {
+		if len(m.name) > 0 {
+			return m.iface.String() + " (" + m.name + ")"
+		}
+		return m.iface.String()
+	}
+	return m.name
+}
+
+type CoverageLine struct {
+	Interface string
+	Method    string
+	Stubs     int
+	Hit       int
+	Calls     int
+}
+
+func (c CoverageLine) String() string {
+	switch {
+	case c.Stubs == 0 && c.Calls == 0:
+		return fmt.Sprintf("%v never stubbed or called", c.Method)
+	case c.Stubs == 0:
+		return fmt.Sprintf("%v never stubbed calls=%v", c.Method, c.Calls)
+	case c.Calls == 0:
+		return fmt.Sprintf("%v never called stubs=%v", c.Method, c.Stubs)
+	}
+	return fmt.Sprintf("%v stubs=%v hit=%v calls=%v", c.Method, c.Stubs, c.Hit, c.Calls)
+}
+
+func Coverage() []CoverageLine {
+	lines := map[string]*CoverageLine{}
+	get := func(label, method string) *CoverageLine {
+		key := label + "\x00" + method
+		line, ok := lines[key]
+		if !ok {
+			line = &CoverageLine{Interface: label, Method: method}
+			lines[key] = line
+		}
+		return line
+	}
+	for _, m := range registry.list() {
+		m.mu.Lock()
+		label := m.label()
+		if m.iface != nil {
+			for index := 0; index < m.iface.NumMethod(); index++ {
+				get(label, m.iface.Method(index).Name)
+			}
+		}
+		for _, name := range m.names {
+			line := get(label, name)
+			for _, item := range m.calls[name].list {
+				count := item.call.results.count()
+				line.Stubs++
+				line.Calls += count
+				if count > 0 {
+					line.Hit++
+				}
+			}
+		}
+		for _, miss := range m.misses {
+			get(label, miss.name).Calls++
+		}
+		m.mu.Unlock()
+	}
+	out := []CoverageLine{}
+	for _, line := range lines {
+		out = append(out, *line)
+	}
+	sort.Slice(out, func(a, b int) bool {
+		if out[a].Interface != out[b].Interface {
+			return out[a].Interface < out[b].Interface
+		}
+		return out[a].Method < out[b].Method
+	})
+	return out
+}
+
+func ResetCoverage() {
+	registry.reset()
+}
+
+func WriteCoverage(w io.Writer) error {
+	out := []string{"Mock coverage:"}
+	current := ""
+	for _, line := range Coverage() {
+		if line.Interface != current || len(out) == 1 {
+			current = line.Interface
+			out = append(out, "\t"+current+":")
+		}
+		out = append(out, "\t\t"+line.String())
+	}
+	_, err := fmt.Fprintln(w, strings.Join(out, "\n"))
+	return err
+}
@@ -0,0 +1,301 @@
+package fsmock
+
+import (
+	".."
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+type Operation struct {
+	Op   string
+	Path string
+	Err  error
+}
+
+type FS struct {
+	*mockband.Mock
+	mu    sync.Mutex
+	nodes map[string]*node
+	ops   []Operation
+}
+
+func New() *FS {
+	return &FS{
+		Mock: mockband.NewMock(),
+		nodes: map[string]*node{
+			".": &node{mode: fs.ModeDir | 0755, modTime: time.Now()},
+		},
+	}
+}
+
+func (f *FS) Open(name string) (fs.File, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin("open", name); err != nil {
+		return nil, err
+	}
+	n, ok := f.nodes[name]
+	if !ok {
+		return nil, f.end("open", name, fs.ErrNotExist)
+	}
+	info := n.info(path.Base(name))
+	if n.mode.IsDir() {
+		return &openDir{info: info, entries: f.entries(name)}, f.end("open", name, nil)
+	}
+	return &openFile{info: info, data: append([]byte{}, n.data...)}, f.end("open", name, nil)
+}
+
+func (f *FS) ReadFile(name string) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin("readfile", name); err != nil {
+		return nil, err
+	}
+	n, ok := f.nodes[name]
+	if !ok {
+		return nil, f.end("readfile", name, fs.ErrNotExist)
+	}
+	if n.mode.IsDir() {
+		return nil, f.end("readfile", name, syscall.EISDIR)
+	}
+	return append([]byte{}, n.data...), f.end("readfile", name, nil)
+}
+
+func (f *FS) ReadDir(name string) ([]fs.DirEntry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin("readdir", name); err != nil {
+		return nil, err
+	}
+	n, ok := f.nodes[name]
+	if !ok {
+		return nil, f.end("readdir", name, fs.ErrNotExist)
+	}
+	if !n.mode.IsDir() {
+		return nil, f.end("readdir", name, syscall.ENOTDIR)
+	}
+	return f.entries(name), f.end("readdir", name, nil)
+}
+
+func (f *FS) Stat(name string) (fs.FileInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin("stat", name); err != nil {
+		return nil, err
+	}
+	n, ok := f.nodes[name]
+	if !ok {
+		return nil, f.end("stat", name, fs.ErrNotExist)
+	}
+	return n.info(path.Base(name)), f.end("stat", name, nil)
+}
+
+func (f *FS) Create(name string) (*File, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin("create", name); err != nil {
+		return nil, err
+	}
+	if err := f.checkParent(name); err != nil {
+		return nil, f.end("create", name, err)
+	}
+	if n, ok := f.nodes[name]; ok && n.mode.IsDir() {
+		return nil, f.end("create", name, syscall.EISDIR)
+	}
+	n := &node{mode: 0644, modTime: time.Now()}
+	f.nodes[name] = n
+	return &File{fs: f, name: name, node: n}, f.end("create", name, nil)
+}
+
+func (f *FS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin("writefile", name); err != nil {
+		return err
+	}
+	if err := f.checkParent(name); err != nil {
+		return f.end("writefile", name, err)
+	}
+	if n, ok := f.nodes[name]; ok && n.mode.IsDir() {
+		return f.end("writefile", name, syscall.EISDIR)
+	}
+	f.nodes[name] = &node{data: append([]byte{}, data...), mode: perm, modTime: time.Now()}
+	return f.end("writefile", name, nil)
+}
+
+func (f *FS) MkdirAll(name string, perm fs.FileMode) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin("mkdir", name); err != nil {
+		return err
+	}
+	if name == "." {
+		return f.end("mkdir", name, nil)
+	}
+	current := "."
+	for _, part := range strings.Split(name, "/") {
+		current = path.Join(current, part)
+		n, ok := f.nodes[current]
+		if !ok {
+			f.nodes[current] = &node{mode: fs.ModeDir | perm, modTime: time.Now()}
+		} else if !n.mode.IsDir() {
+			return f.end("mkdir", name, syscall.ENOTDIR)
+		}
+	}
+	return f.end("mkdir", name, nil)
+}
+
+func (f *FS) Remove(name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin("remove", name); err != nil {
+		return err
+	}
+	if _, ok := f.nodes[name]; !ok || name == "." {
+		return f.end("remove", name, fs.ErrNotExist)
+	}
+	if len(f.entries(name)) > 0 {
+		return f.end("remove", name, syscall.ENOTEMPTY)
+	}
+	delete(f.nodes, name)
+	return f.end("remove", name, nil)
+}
+
+func (f *FS) Rename(oldName, newName string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.begin("rename", oldName); err != nil {
+		return err
+	}
+	if !fs.ValidPath(newName) || newName == "." {
+		return f.end("rename", newName, fs.ErrInvalid)
+	}
+	source, ok := f.nodes[oldName]
+	if !ok || oldName == "." {
+		return f.end("rename", oldName, fs.ErrNotExist)
+	}
+	if oldName == newName {
+		return f.end("rename", oldName, nil)
+	}
+	prefix := oldName + "/"
+	if strings.HasPrefix(newName, prefix) {
+		return f.end("rename", newName, fs.ErrInvalid)
+	}
+	if err := f.checkParent(newName); err != nil {
+		return f.end("rename", newName, err)
+	}
+	if target, ok := f.nodes[newName]; ok {
+		switch {
+		case target.mode.IsDir() && !source.mode.IsDir():
+			return f.end("rename", newName, syscall.EISDIR)
+		case !target.mode.IsDir() && source.mode.IsDir():
+			return f.end("rename", newName, syscall.ENOTDIR)
+		case target.mode.IsDir() && len(f.entries(newName)) > 0:
+			return f.end("rename", newName, syscall.ENOTEMPTY)
+		}
+		delete(f.nodes, newName)
+	}
+	moved := map[string]*node{}
+	for key, n := range f.nodes {
+		if key == oldName {
+			moved[newName] = n
+		} else if strings.HasPrefix(key, prefix) {
+			moved[newName+"/"+strings.TrimPrefix(key, prefix)] = n
+		} else {
+			continue
+		}
+		delete(f.nodes, key)
+	}
+	for key, n := range moved {
+		f.nodes[key] = n
+	}
+	return f.end("rename", oldName, nil)
+}
+
+func (f *FS) Operations() []Operation {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Operation{}, f.ops...)
+}
+
+func (f *FS) begin(op, name string) error {
+	if !fs.ValidPath(name) {
+		return f.end(op, name, fs.ErrInvalid)
+	}
+	if f.Stubbed(op, name) {
+		if err := f.Called(op, name).Get(0).Error(); err != nil {
+			return f.end(op, name, err)
+		}
+	}
+	return nil
+}
+
+func (f *FS) end(op, name string, err error) error {
+	if err != nil {
+		err = &fs.PathError{Op: op, Path: name, Err: err}
+	}
+	f.ops = append(f.ops, Operation{op, name, err})
+	return err
+}
+
+func (f *FS) checkParent(name string) error {
+	if name == "." {
+		return fs.ErrInvalid
+	}
+	parent, ok := f.nodes[path.Dir(name)]
+	if !ok {
+		return fs.ErrNotExist
+	}
+	if !parent.mode.IsDir() {
+		return syscall.ENOTDIR
+	}
+	return nil
+}
+
+func (f *FS) entries(dir string) []fs.DirEntry {
+	out := []fs.DirEntry{}
+	for key, n := range f.nodes {
+		if key != "." && path.Dir(key) == dir {
+			out = append(out, n.info(path.Base(key)))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Name() < out[j].Name()
+	})
+	return out
+}
+
+type File struct {
+	fs   *FS
+	name string
+	node *node
+}
+
+func (f *File) Name() string {
+	return f.name
+}
+
+func (f *File) Write(data []byte) (int, error) {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+	if err := f.fs.begin("write", f.name); err != nil {
+		return 0, err
+	}
+	f.node.data = append(f.node.data, data...)
+	f.node.modTime = time.Now()
+	return len(data), f.fs.end("write", f.name, nil)
+}
+
+func (f *File) Close() error {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+	if err := f.fs.begin("close", f.name); err != nil {
+		return err
+	}
+	return f.fs.end("close", f.name, nil)
+}
@@ -0,0 +1,125 @@
+package fsmock_test
+
+import (
+	"."
+
+	"../../common"
+	"../../reckon"
+	"../../suiteshop"
+
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"syscall"
+	"testing"
+	"testing/fstest"
+)
+
+func Test(t *testing.T) {
+	list := []string{}
+	hasErrors := suiteshop.Describe("FSMock", func(suite *suiteshop.Suite) {
+		suite.Test("fs.FS", func(log *suiteshop.Log) {
+			fsys := fsmock.New()
+			reckon.That(fsys.MkdirAll("config/env", 0755)).Is.Nil()
+			reckon.That(fsys.WriteFile("config/app.json", []byte("{}"), 0644)).Is.Nil()
+			reckon.That(fsys.WriteFile("config/env/dev.json", []byte(`{"env":"dev"}`), 0644)).Is.Nil()
+			reckon.That(fstest.TestFS(fsys, "config/app.json", "config/env/dev.json")).Is.Nil()
+		})
+		suite.Test("Create & Read", func(log *suiteshop.Log) {
+			fsys := fsmock.New()
+			file, err := fsys.Create("out.txt")
+			reckon.That(err).Is.Nil()
+			file.Write([]byte("hello "))
+			file.Write([]byte("world"))
+			reckon.That(file.Close()).Is.Nil()
+			data, err := fs.ReadFile(fsys, "out.txt")
+			reckon.That(err).Is.Nil()
+			reckon.That(string(data)).Is.EqualTo("hello world")
+			info, err := fs.Stat(fsys, "out.txt")
+			reckon.That(info.Size()).Is.EqualTo(int64(11))
+			_, err = fsys.Create("missing/out.txt")
+			reckon.That(errors.Is(err, fs.ErrNotExist)).Is.True()
+		})
+		suite.Test("Remove & Rename", func(log *suiteshop.Log) {
+			fsys := fsmock.New()
+			fsys.MkdirAll("a/b", 0755)
+			fsys.WriteFile("a/b/c.txt", []byte("c"), 0644)
+			reckon.That(fsys.Remove("a")).Is.Not.Nil()
+			err := fsys.Rename("a", "a/b/a")
+			reckon.That(errors.Is(err, fs.ErrInvalid)).Is.True()
+			_, err = fsys.Stat("a/b/c.txt")
+			reckon.That(err).Is.Nil()
+			reckon.That(fsys.Rename("a", "ab")).Is.Nil()
+			reckon.That(fsys.Rename("ab", "a")).Is.Nil()
+			reckon.That(fsys.Rename("a", "z")).Is.Nil()
+			data, err := fsys.ReadFile("z/b/c.txt")
+			reckon.That(err).Is.Nil()
+			reckon.That(string(data)).Is.EqualTo("c")
+			_, err = fsys.Stat("a")
+			reckon.That(errors.Is(err, fs.ErrNotExist)).Is.True()
+			reckon.That(fsys.Remove("z/b/c.txt")).Is.Nil()
+			reckon.That(fsys.Remove("z/b")).Is.Nil()
+			entries, _ := fsys.ReadDir("z")
+			reckon.That(entries).Has.Length.LessThan(1)
+		})
+		suite.Test("Rename onto existing targets", func(log *suiteshop.Log) {
+			fsys := fsmock.New()
+			fsys.MkdirAll("a", 0755)
+			fsys.MkdirAll("b", 0755)
+			fsys.MkdirAll("empty", 0755)
+			fsys.WriteFile("a/x", []byte("x"), 0644)
+			fsys.WriteFile("b/y", []byte("y"), 0644)
+			fsys.WriteFile("b/z", []byte("z"), 0644)
+			err := fsys.Rename("a", "b")
+			reckon.That(errors.Is(err, syscall.ENOTEMPTY)).Is.True()
+			entries, _ := fsys.ReadDir("b")
+			reckon.That(entries).Has.Length.LessThan(3)
+			err = fsys.Rename("b/y", "b")
+			reckon.That(errors.Is(err, syscall.EISDIR)).Is.True()
+			_, err = fsys.Stat("b/y")
+			reckon.That(err).Is.Nil()
+			err = fsys.Rename("a", "b/z")
+			reckon.That(errors.Is(err, syscall.ENOTDIR)).Is.True()
+			reckon.That(fsys.Rename("b/y", "b/z")).Is.Nil()
+			data, err := fsys.ReadFile("b/z")
+			reckon.That(err).Is.Nil()
+			reckon.That(string(data)).Is.EqualTo("y")
+			reckon.That(fsys.Rename("a", "empty")).Is.Nil()
+			data, err = fsys.ReadFile("empty/x")
+			reckon.That(err).Is.Nil()
+			reckon.That(string(data)).Is.EqualTo("x")
+			reckon.That(fsys.Rename("empty", "empty")).Is.Nil()
+		})
+		suite.Test("fault injection", func(log *suiteshop.Log) {
+			fsys := fsmock.New()
+			fsys.WriteFile("config.yaml", []byte("a: 1"), 0644)
+			failure := errors.New("disk on fire")
+			fsys.When("open", "config.yaml").Return(failure).Return(nil)
+			fsys.When("writefile", common.Regex(`\.lock$`)).Return(failure)
+			_, err := fsys.Open("config.yaml")
+			reckon.That(errors.Is(err, failure)).Is.True()
+			_, err = fsys.Open("config.yaml")
+			reckon.That(err).Is.Nil()
+			reckon.That(errors.Is(fsys.WriteFile("app.lock", nil, 0644), failure)).Is.True()
+			reckon.That(fsys.HasCalled("open", "config.yaml").Twice()).Is.True()
+		})
+		suite.Test("Operations", func(log *suiteshop.Log) {
+			fsys := fsmock.New()
+			fsys.WriteFile("a.txt", []byte("a"), 0644)
+			fsys.Stat("b.txt")
+			ops := fsys.Operations()
+			reckon.That(len(ops)).Is.EqualTo(2)
+			reckon.That(ops[0]).Is.EqualTo(fsmock.Operation{"writefile", "a.txt", nil})
+			reckon.That(ops[1].Op).Is.EqualTo("stat")
+			reckon.That(errors.Is(ops[1].Err, fs.ErrNotExist)).Is.True()
+		})
+	}).Post(func(message string) {
+		list = append(list, message)
+	})
+	if hasErrors {
+		t.Fatal(strings.Join(list, "\n"))
+	} else {
+		fmt.Println(strings.Join(list, "\n"))
+	}
+}
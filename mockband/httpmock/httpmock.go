@@ -0,0 +1,252 @@
+package httpmock
+
+import (
+	".."
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Request struct {
+	Method string
+	URL    *url.URL
+	Header http.Header
+	Body   []byte
+}
+
+type RequestMatcher struct {
+	method  string
+	pattern *regexp.Regexp
+	headers http.Header
+	body    interface{}
+	hasBody bool
+}
+
+func Match(method, pattern string) *RequestMatcher {
+	return &RequestMatcher{
+		method:  strings.ToUpper(method),
+		pattern: regexp.MustCompile(pattern),
+		headers: http.Header{},
+	}
+}
+
+func (r *RequestMatcher) Header(key, value string) *RequestMatcher {
+	r.headers.Add(key, value)
+	return r
+}
+
+func (r *RequestMatcher) BodyJSON(value interface{}) *RequestMatcher {
+	data, err := json.Marshal(value)
+	if err != nil {
+		panic(err)
+	}
+	if err := json.Unmarshal(data, &r.body); err != nil {
+		panic(err)
+	}
+	r.hasBody = true
+	return r
+}
+
+func (r *RequestMatcher) Match(actual interface{}) bool {
+	req, ok := actual.(*Request)
+	if !ok {
+		return false
+	}
+	if len(r.method) > 0 && r.method != "*" && r.method != req.Method {
+		return false
+	}
+	if !r.pattern.MatchString(req.URL.String()) {
+		return false
+	}
+	for key, values := range r.headers {
+		for _, value := range values {
+			if !contains(req.Header.Values(key), value) {
+				return false
+			}
+		}
+	}
+	if r.hasBody {
+		var body interface{}
+		if err := json.Unmarshal(req.Body, &body); err != nil {
+			return false
+		}
+		return reflect.DeepEqual(r.body, body)
+	}
+	return true
+}
+
+type Reply struct {
+	Status int
+	Header http.Header
+	Body   []byte
+	Delay  time.Duration
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string {
+	return "httpmock: timeout"
+}
+
+func (timeoutError) Timeout() bool {
+	return true
+}
+
+func (timeoutError) Temporary() bool {
+	return true
+}
+
+var ErrTimeout error = timeoutError{}
+
+type Transport struct {
+	*mockband.Mock
+	mu       sync.Mutex
+	requests []*Request
+}
+
+func NewTransport() *Transport {
+	return &Transport{Mock: mockband.NewMock()}
+}
+
+func (t *Transport) Stub(matcher *RequestMatcher) *Stub {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.When("RoundTrip", matcher)
+	return &Stub{t, matcher}
+}
+
+func (t *Transport) Requests() []*Request {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]*Request{}, t.requests...)
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	snapshot, err := capture(req)
+	if err != nil {
+		return nil, err
+	}
+	reply, err := t.dispatch(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	switch value := reply.(type) {
+	case *Reply:
+		return t.respond(req, value)
+	case error:
+		return nil, value
+	default:
+		return nil, fmt.Errorf("httpmock: unsupported reply: %v", value)
+	}
+}
+
+func (t *Transport) dispatch(snapshot *Request) (reply interface{}, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("httpmock: %v", r)
+		}
+	}()
+	t.requests = append(t.requests, snapshot)
+	if !t.Stubbed("RoundTrip", snapshot) {
+		return nil, fmt.Errorf("httpmock: no stub matches %v %v", snapshot.Method, snapshot.URL)
+	}
+	return t.Called("RoundTrip", snapshot).Get(0).Elem(), nil
+}
+
+func capture(req *http.Request) (*Request, error) {
+	body := []byte{}
+	if req.Body != nil {
+		data, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = data
+	}
+	u := *req.URL
+	return &Request{req.Method, &u, req.Header.Clone(), body}, nil
+}
+
+func (t *Transport) respond(req *http.Request, reply *Reply) (*http.Response, error) {
+	if reply.Delay > 0 {
+		timer := t.Clock().NewTimer(reply.Delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C():
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+	header := reply.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", reply.Status, http.StatusText(reply.Status)),
+		StatusCode:    reply.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(reply.Body)),
+		ContentLength: int64(len(reply.Body)),
+		Request:       req,
+	}, nil
+}
+
+type Stub struct {
+	transport *Transport
+	matcher   *RequestMatcher
+}
+
+func (s *Stub) Reply(reply *Reply) *Stub {
+	return s.add(reply)
+}
+
+func (s *Stub) Respond(status int, body string) *Stub {
+	return s.add(&Reply{Status: status, Body: []byte(body)})
+}
+
+func (s *Stub) RespondJSON(status int, value interface{}) *Stub {
+	data, err := json.Marshal(value)
+	if err != nil {
+		panic(err)
+	}
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	return s.add(&Reply{Status: status, Header: header, Body: data})
+}
+
+func (s *Stub) Fail(err error) *Stub {
+	return s.add(err)
+}
+
+func (s *Stub) Timeout() *Stub {
+	return s.add(ErrTimeout)
+}
+
+func (s *Stub) add(value interface{}) *Stub {
+	s.transport.mu.Lock()
+	defer s.transport.mu.Unlock()
+	s.transport.When("RoundTrip", s.matcher).Return(value)
+	return s
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
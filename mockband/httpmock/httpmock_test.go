@@ -0,0 +1,116 @@
+package httpmock_test
+
+import (
+	"."
+
+	"../../clock"
+	"../../reckon"
+	"../../suiteshop"
+
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test(t *testing.T) {
+	list := []string{}
+	hasErrors := suiteshop.Describe("HTTPMock", func(suite *suiteshop.Suite) {
+		suite.Test("responses", func(log *suiteshop.Log) {
+			transport := httpmock.NewTransport()
+			users := httpmock.Match("GET", `/users/\d+$`).Header("Accept", "application/json")
+			transport.Stub(users).
+				RespondJSON(200, map[string]interface{}{"id": 1}).
+				Respond(404, "missing")
+			client := &http.Client{Transport: transport}
+			req, _ := http.NewRequest("GET", "https://api.example.com/users/1", nil)
+			req.Header.Set("Accept", "application/json")
+			resp, err := client.Do(req)
+			reckon.That(err).Is.Nil()
+			reckon.That(resp.StatusCode).Is.EqualTo(200)
+			reckon.That(resp.Header.Get("Content-Type")).Is.EqualTo("application/json")
+			body, _ := ioutil.ReadAll(resp.Body)
+			reckon.That(string(body)).Is.EqualTo(`{"id":1}`)
+			resp, err = client.Do(req)
+			reckon.That(resp.StatusCode).Is.EqualTo(404)
+			reckon.That(transport.HasCalled("RoundTrip", users).Twice()).Is.True()
+		})
+		suite.Test("body matching", func(log *suiteshop.Log) {
+			transport := httpmock.NewTransport()
+			transport.Stub(httpmock.Match("POST", `/users$`).BodyJSON(map[string]interface{}{"name": "bob"})).Respond(201, "")
+			transport.Stub(httpmock.Match("*", ".*")).Respond(400, "")
+			client := &http.Client{Transport: transport}
+			resp, err := client.Post("https://api.example.com/users", "application/json", strings.NewReader(`{ "name": "bob" }`))
+			reckon.That(err).Is.Nil()
+			reckon.That(resp.StatusCode).Is.EqualTo(201)
+			resp, err = client.Post("https://api.example.com/users", "application/json", bytes.NewReader([]byte(`{"name":"ann"}`)))
+			reckon.That(resp.StatusCode).Is.EqualTo(400)
+			requests := transport.Requests()
+			reckon.That(len(requests)).Is.EqualTo(2)
+			reckon.That(string(requests[1].Body)).Is.EqualTo(`{"name":"ann"}`)
+			reckon.That(requests[1].Header.Get("Content-Type")).Is.EqualTo("application/json")
+		})
+		suite.Test("errors", func(log *suiteshop.Log) {
+			transport := httpmock.NewTransport()
+			refused := errors.New("connection refused")
+			transport.Stub(httpmock.Match("GET", "/down")).Fail(refused).Timeout()
+			client := &http.Client{Transport: transport}
+			_, err := client.Get("https://api.example.com/down")
+			reckon.That(errors.Is(err, refused)).Is.True()
+			_, err = client.Get("https://api.example.com/down")
+			netErr, ok := err.(net.Error)
+			reckon.That(ok && netErr.Timeout()).Is.True()
+			_, err = client.Get("https://api.example.com/other")
+			reckon.That(err).Is.Not.Nil()
+			reckon.That(err.Error()).Does.Contain("no stub matches GET https://api.example.com/other")
+		})
+		suite.Test("delays honor deadlines", func(log *suiteshop.Log) {
+			transport := httpmock.NewTransport()
+			transport.Stub(httpmock.Match("GET", "/slow")).Reply(&httpmock.Reply{Status: 200, Delay: time.Hour})
+			client := &http.Client{Transport: transport}
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			req, _ := http.NewRequestWithContext(ctx, "GET", "https://api.example.com/slow", nil)
+			_, err := client.Do(req)
+			reckon.That(errors.Is(err, context.DeadlineExceeded)).Is.True()
+		})
+		suite.Test("delays follow the mock clock", func(log *suiteshop.Log) {
+			fake := clock.NewFake(time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC))
+			transport := httpmock.NewTransport()
+			transport.UseClock(fake)
+			transport.Stub(httpmock.Match("GET", "/slow")).Reply(&httpmock.Reply{Status: 200, Body: []byte("late"), Delay: time.Hour})
+			client := &http.Client{Transport: transport}
+			done := make(chan string)
+			go func() {
+				resp, err := client.Get("https://api.example.com/slow")
+				if err != nil {
+					done <- err.Error()
+					return
+				}
+				body, _ := ioutil.ReadAll(resp.Body)
+				done <- string(body)
+			}()
+			fake.BlockUntil(1)
+			select {
+			case <-done:
+				log.Errorf("response arrived before the clock advanced")
+			default:
+			}
+			fake.Advance(time.Hour)
+			reckon.That(<-done).Is.EqualTo("late")
+		})
+	}).Post(func(message string) {
+		list = append(list, message)
+	})
+	if hasErrors {
+		t.Fatal(strings.Join(list, "\n"))
+	} else {
+		fmt.Println(strings.Join(list, "\n"))
+	}
+}
@@ -0,0 +1,203 @@
+package iomock
+
+import (
+	".."
+	"../../common"
+	"bytes"
+	"io"
+	"os"
+)
+
+type Reader struct {
+	*mockband.Mock
+	data    []byte
+	offset  int
+	chunk   int
+	failAt  int
+	failure error
+	closer  *Closer
+}
+
+func NewReader(data []byte) *Reader {
+	return newReader(mockband.NewMock(), data, nil)
+}
+
+func newReader(mock *mockband.Mock, data []byte, closer *Closer) *Reader {
+	r := &Reader{Mock: mock, data: data, failAt: -1, closer: closer}
+	mock.When("Read", common.Any(), common.Any()).Then(r.next)
+	return r
+}
+
+func (r *Reader) ChunkSize(size int) *Reader {
+	r.chunk = size
+	return r
+}
+
+func (r *Reader) FailAfter(size int, err error) *Reader {
+	r.failAt = size
+	r.failure = err
+	return r
+}
+
+func (r *Reader) Read(p []byte) (int, error) {
+	args := r.Called("Read", len(p), r.offset)
+	n := args.Get(0).Elem().(int)
+	copy(p, r.data[r.offset:r.offset+n])
+	r.offset += n
+	return n, args.Get(1).Error()
+}
+
+func (r *Reader) next(args *common.Args) *common.Args {
+	size := args.Get(0).Elem().(int)
+	offset := args.Get(1).Elem().(int)
+	if r.closer != nil && r.closer.closed {
+		return &common.Args{0, os.ErrClosed}
+	}
+	if r.failAt >= 0 && offset >= r.failAt {
+		return &common.Args{0, r.failure}
+	}
+	if offset >= len(r.data) {
+		return &common.Args{0, io.EOF}
+	}
+	n := len(r.data) - offset
+	if r.chunk > 0 && r.chunk < n {
+		n = r.chunk
+	}
+	if r.failAt >= 0 && r.failAt-offset < n {
+		n = r.failAt - offset
+	}
+	if size < n {
+		n = size
+	}
+	return &common.Args{n, nil}
+}
+
+type Writer struct {
+	*mockband.Mock
+	buffer  bytes.Buffer
+	shortAt int
+	failAt  int
+	failure error
+	closer  *Closer
+}
+
+func NewWriter() *Writer {
+	return newWriter(mockband.NewMock(), nil)
+}
+
+func newWriter(mock *mockband.Mock, closer *Closer) *Writer {
+	w := &Writer{Mock: mock, shortAt: -1, failAt: -1, closer: closer}
+	mock.When("Write", common.Any(), common.Any()).Then(w.next)
+	return w
+}
+
+func (w *Writer) ShortWriteAfter(size int) *Writer {
+	w.shortAt = size
+	return w
+}
+
+func (w *Writer) FailAfter(size int, err error) *Writer {
+	w.failAt = size
+	w.failure = err
+	return w
+}
+
+func (w *Writer) Write(p []byte) (int, error) {
+	args := w.Called("Write", len(p), w.buffer.Len())
+	n := args.Get(0).Elem().(int)
+	w.buffer.Write(p[:n])
+	return n, args.Get(1).Error()
+}
+
+func (w *Writer) Bytes() []byte {
+	return w.buffer.Bytes()
+}
+
+func (w *Writer) String() string {
+	return w.buffer.String()
+}
+
+func (w *Writer) next(args *common.Args) *common.Args {
+	size := args.Get(0).Elem().(int)
+	offset := args.Get(1).Elem().(int)
+	if w.closer != nil && w.closer.closed {
+		return &common.Args{0, os.ErrClosed}
+	}
+	if w.failAt >= 0 && offset+size > w.failAt {
+		return &common.Args{w.failAt - offset, w.failure}
+	}
+	if w.shortAt >= 0 && offset+size > w.shortAt {
+		return &common.Args{w.shortAt - offset, io.ErrShortWrite}
+	}
+	return &common.Args{size, nil}
+}
+
+type Closer struct {
+	*mockband.Mock
+	closed  bool
+	failure error
+}
+
+func NewCloser() *Closer {
+	return newCloser(mockband.NewMock())
+}
+
+func newCloser(mock *mockband.Mock) *Closer {
+	c := &Closer{Mock: mock}
+	mock.When("Close").Then(c.next)
+	return c
+}
+
+func (c *Closer) FailClose(err error) *Closer {
+	c.failure = err
+	return c
+}
+
+func (c *Closer) Close() error {
+	return c.Called("Close").Get(0).Error()
+}
+
+func (c *Closer) Closed() bool {
+	return c.closed
+}
+
+func (c *Closer) next(args *common.Args) *common.Args {
+	if c.closed {
+		return &common.Args{os.ErrClosed}
+	}
+	if c.failure != nil {
+		return &common.Args{c.failure}
+	}
+	c.closed = true
+	return &common.Args{nil}
+}
+
+type ReadWriteCloser struct {
+	*mockband.Mock
+	Reader *Reader
+	Writer *Writer
+	Closer *Closer
+}
+
+func NewReadWriteCloser(data []byte) *ReadWriteCloser {
+	mock := mockband.NewMock()
+	closer := newCloser(mock)
+	return &ReadWriteCloser{
+		Mock:   mock,
+		Reader: newReader(mock, data, closer),
+		Writer: newWriter(mock, closer),
+		Closer: closer,
+	}
+}
+
+func (rwc *ReadWriteCloser) Read(p []byte) (int, error) {
+	return rwc.Reader.Read(p)
+}
+
+func (rwc *ReadWriteCloser) Write(p []byte) (int, error) {
+	return rwc.Writer.Write(p)
+}
+
+func (rwc *ReadWriteCloser) Close() error {
+	return rwc.Closer.Close()
+}
@@ -0,0 +1,105 @@
+package iomock_test
+
+import (
+	"."
+
+	"../../common"
+	"../../reckon"
+	"../../suiteshop"
+
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+func Test(t *testing.T) {
+	list := []string{}
+	hasErrors := suiteshop.Describe("IOMock", func(suite *suiteshop.Suite) {
+		suite.Describe("Reader", func(suite *suiteshop.Suite) {
+			suite.Test("chunks", func(log *suiteshop.Log) {
+				reader := iomock.NewReader([]byte("abcdefg")).ChunkSize(3)
+				data, err := ioutil.ReadAll(reader)
+				reckon.That(err).Is.Nil()
+				reckon.That(string(data)).Is.EqualTo("abcdefg")
+				calls := reader.GetCalls("Read")
+				reckon.That(calls.GetResults(0)).Is.EqualTo(&common.Args{3, nil})
+				reckon.That(calls.GetParams(1).Get(1).Int()).Is.EqualTo(3)
+				reckon.That(calls.GetResults(2)).Is.EqualTo(&common.Args{1, nil})
+				reckon.That(calls.GetResults(3)).Is.EqualTo(&common.Args{0, io.EOF})
+			})
+			suite.Test("fail mid-stream", func(log *suiteshop.Log) {
+				reader := iomock.NewReader([]byte("abcdefg")).FailAfter(4, io.ErrUnexpectedEOF)
+				data, err := ioutil.ReadAll(reader)
+				reckon.That(err).Is.EqualTo(io.ErrUnexpectedEOF)
+				reckon.That(string(data)).Is.EqualTo("abcd")
+				_, err = io.ReadFull(iomock.NewReader([]byte("ab")), make([]byte, 4))
+				reckon.That(err).Is.EqualTo(io.ErrUnexpectedEOF)
+			})
+		})
+		suite.Describe("Writer", func(suite *suiteshop.Suite) {
+			suite.Test("records", func(log *suiteshop.Log) {
+				writer := iomock.NewWriter()
+				fmt.Fprint(writer, "hello")
+				fmt.Fprint(writer, " world")
+				reckon.That(writer.String()).Is.EqualTo("hello world")
+				reckon.That(writer.HasCalled("Write").Twice()).Is.True()
+				reckon.That(writer.GetCalls("Write").GetParams(1)).Is.EqualTo(&common.Args{6, 5})
+			})
+			suite.Test("short write", func(log *suiteshop.Log) {
+				writer := iomock.NewWriter().ShortWriteAfter(3)
+				n, err := writer.Write([]byte("abcde"))
+				reckon.That(n).Is.EqualTo(3)
+				reckon.That(err).Is.EqualTo(io.ErrShortWrite)
+				reckon.That(writer.String()).Is.EqualTo("abc")
+			})
+			suite.Test("fail", func(log *suiteshop.Log) {
+				failure := errors.New("disk full")
+				writer := iomock.NewWriter().FailAfter(4, failure)
+				n, err := writer.Write([]byte("abc"))
+				reckon.That(n).Is.EqualTo(3)
+				reckon.That(err).Is.Nil()
+				n, err = writer.Write([]byte("def"))
+				reckon.That(n).Is.EqualTo(1)
+				reckon.That(err).Is.EqualTo(failure)
+				reckon.That(writer.GetCalls("Write").GetResults(1)).Is.EqualTo(&common.Args{1, failure})
+			})
+		})
+		suite.Describe("ReadWriteCloser", func(suite *suiteshop.Suite) {
+			suite.Test("shared history", func(log *suiteshop.Log) {
+				rwc := iomock.NewReadWriteCloser([]byte("ping"))
+				var stream io.ReadWriteCloser = rwc
+				data := make([]byte, 4)
+				stream.Read(data)
+				stream.Write([]byte("pong"))
+				reckon.That(stream.Close()).Is.Nil()
+				reckon.That(rwc.Closer.Closed()).Is.True()
+				_, err := stream.Write([]byte("late"))
+				reckon.That(err).Is.EqualTo(os.ErrClosed)
+				reckon.That(stream.Close()).Is.EqualTo(os.ErrClosed)
+				reckon.That(rwc.HasCalled("Read").Once()).Is.True()
+				reckon.That(rwc.HasCalled("Write").Twice()).Is.True()
+				reckon.That(rwc.HasCalled("Close").Twice()).Is.True()
+				reckon.That(rwc.Writer.String()).Is.EqualTo("pong")
+			})
+			suite.Test("close failure", func(log *suiteshop.Log) {
+				failure := errors.New("busy")
+				rwc := iomock.NewReadWriteCloser(nil)
+				rwc.Closer.FailClose(failure)
+				reckon.That(rwc.Close()).Is.EqualTo(failure)
+				reckon.That(rwc.Closer.Closed()).Is.False()
+				reckon.That(strings.Contains(rwc.GetCalls("Close").GetResults(0).Get(0).String(), "busy")).Is.True()
+			})
+		})
+	}).Post(func(message string) {
+		list = append(list, message)
+	})
+	if hasErrors {
+		t.Fatal(strings.Join(list, "\n"))
+	} else {
+		fmt.Println(strings.Join(list, "\n"))
+	}
+}
@@ -0,0 +1,197 @@
+package mockband
+
+import (
+	"../common"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	JournalText = "text"
+	JournalJSON = "json"
+)
+
+type journal struct {
+	Stubs []journalStub `json:"stubs"`
+	Calls []journalCall `json:"calls"`
+}
+
+type journalStub struct {
+	Method    string   `json:"method"`
+	Params    []string `json:"params"`
+	Responses int      `json:"responses"`
+	Sequence  string   `json:"sequence"`
+	Delay     string   `json:"delay,omitempty"`
+	Calls     int      `json:"calls"`
+}
+
+type journalCall struct {
+	Order   int      `json:"order"`
+	Method  string   `json:"method"`
+	Params  []string `json:"params"`
+	Results []string `json:"results"`
+	Panic   string   `json:"panic,omitempty"`
+	Matched bool     `json:"matched"`
+}
+
+func (m *Mock) WriteJournal(w io.Writer, format string) error {
+	j := m.journal()
+	switch format {
+	case JournalJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(j)
+	case JournalText:
+		return j.writeText(w)
+	default:
+		return fmt.Errorf("unknown journal format: %v", format)
+	}
+}
+
+func (m *Mock) journal() *journal {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	j := &journal{[]journalStub{}, []journalCall{}}
+	for _, name := range m.names {
+		for _, item := range m.calls[name].list {
+			c := item.call
+			stub := journalStub{
+				Method:    name,
+				Params:    formatArgs(&item.params),
+				Responses: len(c.list),
+				Sequence:  []string{"cycle", "last", "once"}[c.sequence],
+				Calls:     c.results.count(),
+			}
+			if c.delay > 0 {
+				stub.Delay = c.delay.String()
+			}
+			j.Stubs = append(j.Stubs, stub)
+			for _, entry := range c.results.list {
+				j.Calls = append(j.Calls, newJournalCall(entry, true))
+			}
+		}
+	}
+	for _, entry := range m.misses {
+		j.Calls = append(j.Calls, newJournalCall(entry, false))
+	}
+	sort.Slice(j.Calls, func(a, b int) bool {
+		return j.Calls[a].Order < j.Calls[b].Order
+	})
+	return j
+}
+
+func newJournalCall(entry result, matched bool) journalCall {
+	out := journalCall{
+		Order:   entry.order,
+		Method:  entry.name,
+		Params:  formatArgs(entry.params),
+		Results: []string{},
+		Matched: matched,
+	}
+	if entry.results != nil {
+		out.Results = formatArgs(entry.results)
+	}
+	if entry.message != nil {
+		out.Panic = *entry.message
+	}
+	return out
+}
+
+func (j *journal) writeText(w io.Writer) error {
+	lines := []string{"Stubs:"}
+	for _, stub := range j.Stubs {
+		line := fmt.Sprintf("\t%v(%v) responses=%v sequence=%v calls=%v",
+			stub.Method, strings.Join(stub.Params, ", "), stub.Responses, stub.Sequence, stub.Calls)
+		if len(stub.Delay) > 0 {
+			line += " delay=" + stub.Delay
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, "Calls:")
+	for _, c := range j.Calls {
+		line := fmt.Sprintf("\t#%v %v(%v)", c.Order, c.Method, strings.Join(c.Params, ", "))
+		if !c.Matched {
+			line += " unmatched"
+		} else if len(c.Panic) > 0 {
+			line += " panic: " + c.Panic
+		} else {
+			line += " -> (" + strings.Join(c.Results, ", ") + ")"
+		}
+		lines = append(lines, line)
+	}
+	_, err := fmt.Fprintln(w, strings.Join(lines, "\n"))
+	return err
+}
+
+func formatArgs(args *common.Args) []string {
+	out := []string{}
+	args.Each(func(item *common.Arg, index int) {
+		out = append(out, formatArg(item.Elem()))
+	})
+	return out
+}
+
+func formatArg(value interface{}) string {
+	if reflect.DeepEqual(value, common.Any()) {
+		return "<any>"
+	}
+	if _, ok := value.(common.Matcher); ok {
+		return "<matcher>"
+	}
+	if _, ok := value.(string); ok {
+		return fmt.Sprintf("%q", value)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+type TB interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+	Name() string
+	Failed() bool
+	Cleanup(fn func())
+	Logf(format string, args ...interface{})
+}
+
+var unsafeName = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+func DumpOnFailure(t TB, dir string, format string, mocks ...*Mock) {
+	t.Cleanup(func() {
+		if !t.Failed() {
+			return
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Logf("mock journal: %v", err)
+			return
+		}
+		extension := ".log"
+		if format == JournalJSON {
+			extension = ".json"
+		}
+		base := unsafeName.ReplaceAllString(t.Name(), "_")
+		for index, mock := range mocks {
+			path := filepath.Join(dir, fmt.Sprintf("%v-%v%v", base, index, extension))
+			if err := writeJournalFile(mock, path, format); err != nil {
+				t.Logf("mock journal: %v", err)
+			} else {
+				t.Logf("mock journal written to %v", path)
+			}
+		}
+	})
+}
+
+func writeJournalFile(mock *Mock, path, format string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return mock.WriteJournal(file, format)
+}
@@ -0,0 +1,224 @@
+package mockband
+
+import (
+	"../common"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"regexp"
+)
+
+func LoadStubs(mock *Mock, reader io.Reader) error {
+	return NewLoader().Load(mock, reader)
+}
+
+func LoadYAMLStubs(mock *Mock, reader io.Reader, unmarshal func(data []byte, v interface{}) error) error {
+	return NewLoader().Format(unmarshal).Load(mock, reader)
+}
+
+type Loader struct {
+	types     map[string]reflect.Type
+	unmarshal func(data []byte, v interface{}) error
+}
+
+func NewLoader() *Loader {
+	loader := &Loader{map[string]reflect.Type{}, json.Unmarshal}
+	for _, sample := range []interface{}{
+		"", false, []byte{}, 0.0, float32(0),
+		0, int8(0), int16(0), int32(0), int64(0),
+		uint(0), uint8(0), uint16(0), uint32(0), uint64(0),
+	} {
+		loader.Register(reflect.TypeOf(sample).String(), sample)
+	}
+	return loader
+}
+
+func (l *Loader) Register(name string, sample interface{}) *Loader {
+	l.types[name] = reflect.TypeOf(sample)
+	return l
+}
+
+func (l *Loader) Format(unmarshal func(data []byte, v interface{}) error) *Loader {
+	l.unmarshal = unmarshal
+	return l
+}
+
+func (l *Loader) Load(mock *Mock, reader io.Reader) error {
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	var raw interface{}
+	if err := l.unmarshal(data, &raw); err != nil {
+		return err
+	}
+	doc, ok := normalize(raw).(map[string]interface{})
+	if !ok {
+		return errors.New("stub document is not an object")
+	}
+	stubs, ok := doc["stubs"].([]interface{})
+	if !ok {
+		return errors.New("stub document has no stubs list")
+	}
+	for index, item := range stubs {
+		stub, ok := item.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("stub %v is not an object", index)
+		}
+		if err := l.loadStub(mock, stub); err != nil {
+			return fmt.Errorf("stub %v: %v", index, err)
+		}
+	}
+	return nil
+}
+
+func (l *Loader) loadStub(mock *Mock, stub map[string]interface{}) error {
+	method, ok := stub["method"].(string)
+	if !ok || len(method) == 0 {
+		return errors.New("missing method name")
+	}
+	params, err := l.loadArgs(stub["args"], true)
+	if err != nil {
+		return err
+	}
+	responses := []interface{}{}
+	if sequence, ok := stub["sequence"].([]interface{}); ok {
+		responses = sequence
+	} else {
+		responses = append(responses, stub)
+	}
+	c := mock.When(method, params...)
+	for _, item := range responses {
+		response, ok := item.(map[string]interface{})
+		if !ok {
+			return errors.New("response is not an object")
+		}
+		if message, ok := response["panic"]; ok {
+			c.Panic(fmt.Sprintf("%v", message))
+			continue
+		}
+		values, err := l.loadArgs(response["returns"], false)
+		if err != nil {
+			return err
+		}
+		c.Return(values...)
+	}
+	switch stub["repeat"] {
+	case nil, "cycle":
+	case "last":
+		c.RepeatLast()
+	case "once":
+		c.Exhaust()
+	default:
+		return fmt.Errorf("unknown repeat mode: %v", stub["repeat"])
+	}
+	return nil
+}
+
+func (l *Loader) loadArgs(raw interface{}, matching bool) ([]interface{}, error) {
+	if raw == nil {
+		return []interface{}{}, nil
+	}
+	list, ok := raw.([]interface{})
+	if !ok {
+		return nil, errors.New("arguments are not a list")
+	}
+	out := []interface{}{}
+	for _, item := range list {
+		value, err := l.loadValue(item, matching)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, value)
+	}
+	return out, nil
+}
+
+func (l *Loader) loadValue(raw interface{}, matching bool) (interface{}, error) {
+	if matching && isNumeric(raw) {
+		return common.Range(raw, raw), nil
+	}
+	obj, ok := raw.(map[string]interface{})
+	if !ok {
+		return raw, nil
+	}
+	if value, ok := obj["$literal"]; ok {
+		return value, nil
+	}
+	if name, ok := obj["$type"]; ok {
+		return l.decode(fmt.Sprintf("%v", name), obj["value"])
+	}
+	if !matching {
+		return raw, nil
+	}
+	if _, ok := obj["$any"]; ok {
+		return common.Any(), nil
+	}
+	if pattern, ok := obj["$regex"].(string); ok {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("invalid regex %q: %v", pattern, err)
+		}
+		return common.Regex(pattern), nil
+	}
+	if bounds, ok := obj["$range"].([]interface{}); ok {
+		if len(bounds) != 2 || !isNumeric(bounds[0]) || !isNumeric(bounds[1]) {
+			return nil, errors.New("range requires a low and a high number")
+		}
+		return common.Range(bounds[0], bounds[1]), nil
+	}
+	return raw, nil
+}
+
+func (l *Loader) decode(name string, raw interface{}) (interface{}, error) {
+	t, ok := l.types[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown type: %v", name)
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	value := reflect.New(t)
+	if err := json.Unmarshal(data, value.Interface()); err != nil {
+		return nil, err
+	}
+	return value.Elem().Interface(), nil
+}
+
+func isNumeric(raw interface{}) bool {
+	switch reflect.ValueOf(raw).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func normalize(raw interface{}) interface{} {
+	switch value := raw.(type) {
+	case map[interface{}]interface{}:
+		out := map[string]interface{}{}
+		for key, item := range value {
+			out[fmt.Sprintf("%v", key)] = normalize(item)
+		}
+		return out
+	case map[string]interface{}:
+		out := map[string]interface{}{}
+		for key, item := range value {
+			out[key] = normalize(item)
+		}
+		return out
+	case []interface{}:
+		out := []interface{}{}
+		for _, item := range value {
+			out = append(out, normalize(item))
+		}
+		return out
+	default:
+		return raw
+	}
+}
@@ -1,61 +1,154 @@
 package mockband
 
 import (
+	"../clock"
 	"../common"
 	"fmt"
 	"reflect"
+	"sync"
+	"time"
 )
 
 type Mock struct {
-	calls map[string]*callList
+	calls       map[string]*callList
+	names       []string
+	misses      []result
+	order       int
+	clock       clock.Clock
+	contextKeys []interface{}
+	compare     *common.Comparison
+	name        string
+	iface       reflect.Type
+	sink        *Sink
+	mu          sync.Mutex
 }
 
 func NewMock() *Mock {
-	return &Mock{map[string]*callList{}}
+	return &Mock{map[string]*callList{}, []string{}, []result{}, 0, clock.Real(), []interface{}{}, nil, "", nil, nil, sync.Mutex{}}
+}
+
+func (m *Mock) CompareWith(comparison *common.Comparison) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.compare = comparison
+	return m
+}
+
+func (m *Mock) UseClock(c clock.Clock) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.clock = c
+	return m
+}
+
+func (m *Mock) Clock() clock.Clock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.clock
 }
 
 func (m *Mock) Called(name string, params ...interface{}) *common.Args {
-	call := m.getCall(name, params)
+	return m.called(name, func() (*call, []interface{}) {
+		return m.getCall(name, params), params
+	})
+}
+
+func (m *Mock) called(name string, find func() (*call, []interface{})) *common.Args {
+	m.mu.Lock()
+	call, params := find()
+	args := common.Args(params)
+	m.order++
+	entry := result{
+		name:    name,
+		params:  &args,
+		context: m.captureContext(params),
+		order:   m.order,
+	}
+	sink := m.sink
 	if call == nil {
-		panic("Function with param signature not found: " + name)
-	} else {
-		args := common.Args(params)
-		return call.exec(&args)
+		message := "Function with param signature not found: " + name
+		entry.message = &message
+		m.misses = append(m.misses, entry)
+		zero := m.zeroResults(name)
+		m.mu.Unlock()
+		if sink != nil {
+			sink.Record(message)
+			return zero
+		}
+		panic(message)
+	}
+	fn := call.take()
+	delay := call.delay
+	sleeper := m.clock
+	zero := &common.Args{}
+	if sink != nil && fn == nil {
+		zero = m.zeroResults(name)
+	}
+	m.mu.Unlock()
+	if delay > 0 {
+		sleeper.Sleep(delay)
+	}
+	out, message := execSafe(fn, entry.params)
+	entry.results = out
+	entry.message = &message
+	m.mu.Lock()
+	call.results.add(entry)
+	m.mu.Unlock()
+	if len(message) > 0 {
+		if sink != nil && fn == nil {
+			sink.Record(fmt.Sprintf("%v: %v", message, name))
+			return zero
+		}
+		panic(message)
 	}
+	return out
 }
 
 func (m *Mock) CalledVarArg(name string, params ...interface{}) *common.Args {
 	if len(params) == 0 {
 		return m.Called(name)
-	} else {
-		args := common.Args(params)
-		last := reflect.ValueOf(args.PopLast().Elem())
-		err := args.AddAll(last)
-		if err != nil {
-			args.Add(last)
-		}
-		return m.Called(name, args...)
 	}
+	flat := flatten(params)
+	return m.called(name, func() (*call, []interface{}) {
+		list, ok := m.calls[name]
+		if !ok {
+			return nil, params
+		}
+		return list.getVarCall(params, flat, m.equal())
+	})
 }
 
 func (m *Mock) When(name string, params ...interface{}) *call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	list, ok := m.calls[name]
 	if !ok {
 		list = &callList{}
 		m.calls[name] = list
+		m.names = append(m.names, name)
 	}
-	return list.createCall(params)
+	return list.createCall(params, m.equal(), &m.mu)
+}
+
+func (m *Mock) Stubbed(name string, params ...interface{}) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.getCall(name, params) != nil
 }
 
 func (m *Mock) GetCalls(name string, params ...interface{}) *results {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	call := m.getCall(name, params)
 	if call == nil {
 		panic("Function not found: " + name)
 	}
-	return &call.results
+	return &results{append([]result{}, call.results.list...)}
 }
 
 func (m *Mock) HasCalled(name string, params ...interface{}) *metric {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	call := m.getCall(name, params)
 	if call == nil {
 		panic("Function not found: " + name)
@@ -63,12 +156,49 @@ func (m *Mock) HasCalled(name string, params ...interface{}) *metric {
 	return &metric{call.results.count()}
 }
 
+func (m *Mock) zeroResults(name string) *common.Args {
+	out := common.Args{}
+	if m.iface != nil {
+		if method, ok := m.iface.MethodByName(name); ok {
+			for index := 0; index < method.Type.NumOut(); index++ {
+				out = append(out, reflect.Zero(method.Type.Out(index)).Interface())
+			}
+			return &out
+		}
+	}
+	if list, ok := m.calls[name]; ok {
+		for _, item := range list.list {
+			for _, entry := range item.call.results.list {
+				if entry.results == nil || len(*entry.results) == 0 {
+					continue
+				}
+				for _, value := range *entry.results {
+					if value == nil {
+						out = append(out, nil)
+					} else {
+						out = append(out, reflect.Zero(reflect.TypeOf(value)).Interface())
+					}
+				}
+				return &out
+			}
+		}
+	}
+	return common.ZeroArgs()
+}
+
 func (m *Mock) getCall(name string, params []interface{}) *call {
 	list, ok := m.calls[name]
 	if !ok {
 		return nil
 	}
-	return list.getCall(params)
+	return list.getCall(params, m.equal())
+}
+
+func (m *Mock) equal() func(expected, actual interface{}) bool {
+	if m.compare == nil {
+		return reflect.DeepEqual
+	}
+	return m.compare.Equal
 }
 
 type metric struct {
@@ -103,6 +233,10 @@ func (r *results) GetError(index int) *string {
 	return r.list[index].message
 }
 
+func (r *results) GetContext(index int) *ContextInfo {
+	return r.list[index].context
+}
+
 func (r *results) add(result result) {
 	r.list = append(r.list, result)
 }
@@ -112,41 +246,51 @@ func (r *results) count() int {
 }
 
 type result struct {
+	name    string
 	params  *common.Args
 	results *common.Args
 	message *string
+	context *ContextInfo
+	order   int
 }
 
+const (
+	cycle = iota
+	repeatLast
+	exhaust
+)
+
 type call struct {
-	list    []func(args *common.Args) *common.Args
-	index   int
-	results results
+	list     []func(args *common.Args) *common.Args
+	index    int
+	sequence int
+	delay    time.Duration
+	results  results
+	lock     sync.Locker
 }
 
-func newCall() *call {
+func newCall(lock sync.Locker) *call {
 	return &call{
 		[]func(args *common.Args) *common.Args{},
 		0,
+		cycle,
+		0,
 		results{},
+		lock,
 	}
 }
 
-func (c *call) exec(args *common.Args) *common.Args {
-	message := ""
-	out := &common.Args{}
-	c.execSafe(args, out, &message)
-	c.results.add(result{
-		params:  args,
-		results: out,
-		message: &message,
-	})
-	c.index = (c.index + 1) % len(c.list)
-	if len(message) > 0 {
-		panic(message)
+func (c *call) take() func(args *common.Args) *common.Args {
+	if c.exhausted() {
+		return nil
 	}
-	return out
+	fn := c.list[c.index]
+	c.next()
+	return fn
 }
-func (c *call) execSafe(in, out *common.Args, message *string) {
+
+func execSafe(fn func(args *common.Args) *common.Args, in *common.Args) (out *common.Args, message string) {
+	out = &common.Args{}
 	defer func() {
 		if r := recover(); r != nil {
 			switch r.(type) {
@@ -155,17 +299,57 @@ func (c *call) execSafe(in, out *common.Args, message *string) {
 				if !ok {
 					panic("error not error -- should not reach")
 				}
-				*message = err.Error()
+				message = err.Error()
 			case string:
-				*message = fmt.Sprintf("%v", r)
+				message = fmt.Sprintf("%v", r)
 			default:
-				*message = fmt.Sprintf("unknown error: %v", r)
+				message = fmt.Sprintf("unknown error: %v", r)
 			}
 		}
 	}()
-	fn := c.list[c.index]
+	if fn == nil {
+		panic("Function responses exhausted")
+	}
 	temp := fn(in)
 	*out = *temp
+	return out, message
+}
+
+func (c *call) exhausted() bool {
+	return c.index >= len(c.list)
+}
+
+func (c *call) next() {
+	if c.index+1 < len(c.list) {
+		c.index++
+	} else if c.sequence == repeatLast && len(c.list) > 0 {
+		c.index = len(c.list) - 1
+	} else if c.sequence == exhaust {
+		c.index = len(c.list)
+	} else {
+		c.index = 0
+	}
+}
+
+func (c *call) RepeatLast() *call {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.sequence = repeatLast
+	return c
+}
+
+func (c *call) Exhaust() *call {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.sequence = exhaust
+	return c
+}
+
+func (c *call) Delay(d time.Duration) *call {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.delay = d
+	return c
 }
 
 func (c *call) Return(params ...interface{}) *call {
@@ -191,6 +375,8 @@ func (c *call) Panic(err interface{}) *call {
 }
 
 func (c *call) Then(fn func(args *common.Args) *common.Args) *call {
+	c.lock.Lock()
+	defer c.lock.Unlock()
 	c.list = append(c.list, fn)
 	return c
 }
@@ -204,22 +390,22 @@ type callList struct {
 	list []callListItem
 }
 
-func (c *callList) getCall(params []interface{}) *call {
+func (c *callList) getCall(params []interface{}, equal func(expected, actual interface{}) bool) *call {
 	for _, item := range c.list {
 		args := common.Args(params)
-		if item.params.Matches(&args) {
+		if item.params.MatchesWith(&args, equal) {
 			return item.call
 		}
 	}
 	return nil
 }
 
-func (c *callList) createCall(params []interface{}) *call {
-	me := c.getCall(params)
+func (c *callList) createCall(params []interface{}, equal func(expected, actual interface{}) bool, lock sync.Locker) *call {
+	me := c.getCall(params, equal)
 	if me != nil {
 		return me
 	}
-	me = newCall()
+	me = newCall(lock)
 	c.list = append(c.list, callListItem{
 		params: common.Args(params),
 		call:   me,
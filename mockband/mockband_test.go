@@ -3,13 +3,21 @@ package mockband_test
 import (
 	"."
 
+	"../clock"
 	"../common"
 	"../reckon"
 	"../suiteshop"
 
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"os"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func Test(t *testing.T) {
@@ -148,6 +156,592 @@ func Test(t *testing.T) {
 				reckon.That(params.Get(0).String()).Is.EqualTo("Third")
 			})
 		})
+		suite.Describe("LoadStubs", func(suite *suiteshop.Suite) {
+			suite.Test("matchers", func(log *suiteshop.Log) {
+				mock := NewMockObject()
+				var obj Object = mock
+				err := mockband.LoadStubs(mock.Mock, strings.NewReader(`{"stubs": [
+					{"method": "Method1", "args": [{"$regex": "^first"}, {"$range": [1, 10]}]},
+					{"method": "Method1", "args": [{"$any": true}, 42]}
+				]}`))
+				reckon.That(err).Is.Nil()
+				obj.Method1("first value", 7)
+				obj.Method1("any value", 42)
+				reckon.That(func() {
+					obj.Method1("second value", 7)
+				}).Will.Panic()
+				reckon.That(mock.HasCalled("Method1", "first value", 7).Once()).Is.True()
+				reckon.That(mock.HasCalled("Method1", "any value", 42).Once()).Is.True()
+			})
+			suite.Test("returns and types", func(log *suiteshop.Log) {
+				mock := NewMockObject()
+				var obj Object = mock
+				loader := mockband.NewLoader().Register("Point", point{})
+				err := loader.Load(mock.Mock, strings.NewReader(`{"stubs": [
+					{"method": "Method2", "returns": ["value", {"$type": "int", "value": 3}, null]},
+					{"method": "Method3", "args": ["point"], "returns": [{"$type": "Point", "value": {"X": 1, "Y": 2}}]}
+				]}`))
+				reckon.That(err).Is.Nil()
+				str, num, err := obj.Method2()
+				reckon.That(str).Is.EqualTo("value")
+				reckon.That(num).Is.EqualTo(3)
+				reckon.That(err).Is.Nil()
+				reckon.That(obj.Method3("point")).Is.EqualTo(point{1, 2})
+			})
+			suite.Test("sequence", func(log *suiteshop.Log) {
+				mock := NewMockObject()
+				var obj Object = mock
+				err := mockband.LoadStubs(mock.Mock, strings.NewReader(`{"stubs": [
+					{"method": "Method3", "repeat": "last", "sequence": [
+						{"panic": "First"},
+						{"returns": ["Second"]}
+					]},
+					{"method": "Method3", "args": ["once"], "repeat": "once", "returns": ["Only"]}
+				]}`))
+				reckon.That(err).Is.Nil()
+				reckon.That(func() { obj.Method3() }).Will.PanicWith("First")
+				reckon.That(obj.Method3()).Is.EqualTo("Second")
+				reckon.That(obj.Method3()).Is.EqualTo("Second")
+				reckon.That(obj.Method3("once")).Is.EqualTo("Only")
+				reckon.That(func() { obj.Method3("once") }).Will.PanicWith("Function responses exhausted")
+			})
+			suite.Test("custom format", func(log *suiteshop.Log) {
+				mock := NewMockObject()
+				var obj Object = mock
+				unmarshal := func(data []byte, v interface{}) error {
+					*(v.(*interface{})) = map[interface{}]interface{}{
+						"stubs": []interface{}{
+							map[interface{}]interface{}{"method": "Method3", "returns": []interface{}{string(data)}},
+						},
+					}
+					return nil
+				}
+				err := mockband.NewLoader().Format(unmarshal).Load(mock.Mock, strings.NewReader("from yaml"))
+				reckon.That(err).Is.Nil()
+				reckon.That(obj.Method3()).Is.EqualTo("from yaml")
+			})
+			suite.Test("yaml", func(log *suiteshop.Log) {
+				mock := NewMockObject()
+				var obj Object = mock
+				document := strings.Join([]string{
+					"stubs:",
+					"  - method: Method4",
+					"    args:",
+					"      - $type: Point",
+					"        value: {X: 1, Y: 2}",
+					"    returns: [null]",
+					"  - method: Method5",
+					"    args: [{$any: true}, {$range: [1, 10]}]",
+					"    repeat: last",
+					"    sequence:",
+					"      - returns: [first, null]",
+					"      - returns: [second, null]",
+				}, "\n")
+				unmarshal := func(data []byte, v interface{}) error {
+					reckon.That(string(data)).Is.EqualTo(document)
+					*(v.(*interface{})) = map[interface{}]interface{}{
+						"stubs": []interface{}{
+							map[interface{}]interface{}{
+								"method": "Method4",
+								"args": []interface{}{
+									map[interface{}]interface{}{
+										"$type": "Point",
+										"value": map[interface{}]interface{}{"X": 1, "Y": 2},
+									},
+								},
+								"returns": []interface{}{nil},
+							},
+							map[interface{}]interface{}{
+								"method": "Method5",
+								"args": []interface{}{
+									map[interface{}]interface{}{"$any": true},
+									map[interface{}]interface{}{"$range": []interface{}{1, 10}},
+								},
+								"repeat": "last",
+								"sequence": []interface{}{
+									map[interface{}]interface{}{"returns": []interface{}{"first", nil}},
+									map[interface{}]interface{}{"returns": []interface{}{"second", nil}},
+								},
+							},
+						},
+					}
+					return nil
+				}
+				loader := mockband.NewLoader().Register("Point", point{}).Format(unmarshal)
+				reckon.That(loader.Load(mock.Mock, strings.NewReader(document))).Is.Nil()
+				reckon.That(obj.Method4(point{1, 2})).Is.Nil()
+				value, err := obj.Method5(context.Background(), 4)
+				reckon.That(value).Is.EqualTo("first")
+				reckon.That(err).Is.Nil()
+				value, _ = obj.Method5(context.Background(), 7)
+				reckon.That(value).Is.EqualTo("second")
+				reckon.That(func() { obj.Method5(context.Background(), 11) }).Will.Panic()
+				other := NewMockObject()
+				err = mockband.LoadYAMLStubs(other.Mock, strings.NewReader(document), unmarshal)
+				reckon.That(err.Error()).Is.EqualTo("stub 0: unknown type: Point")
+			})
+			suite.Test("errors", func(log *suiteshop.Log) {
+				mock := mockband.NewMock()
+				reckon.That(mockband.LoadStubs(mock, strings.NewReader(`[]`))).Is.Not.Nil()
+				reckon.That(mockband.LoadStubs(mock, strings.NewReader(`{"stubs": [{"args": []}]}`))).Is.Not.Nil()
+				reckon.That(mockband.LoadStubs(mock, strings.NewReader(`{"stubs": [{"method": "A", "args": [{"$type": "Unknown"}]}]}`))).Is.Not.Nil()
+				err := mockband.LoadStubs(mock, strings.NewReader(`{"stubs": [{"method": "A", "args": [{"$regex": "("}]}]}`))
+				reckon.That(err.Error()).Does.Contain("stub 0: invalid regex \"(\": ")
+			})
+		})
+		suite.Test("Delay", func(log *suiteshop.Log) {
+			fake := clock.NewFake(time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC))
+			mock := NewMockObject()
+			mock.UseClock(fake)
+			var obj Object = mock
+			mock.When("Method3").Delay(time.Minute).Return("late")
+			done := make(chan interface{})
+			go func() {
+				done <- obj.Method3()
+			}()
+			fake.BlockUntil(1)
+			fake.Advance(time.Minute)
+			reckon.That(<-done).Is.EqualTo("late")
+		})
+		suite.Test("Context", func(log *suiteshop.Log) {
+			type key string
+			mock := NewMockObject().RecordContextKeys(key("user"))
+			var obj Object = mock
+			mock.When("Method5", common.CtxCancelled(), common.Any()).Return("", context.Canceled)
+			mock.When("Method5", context.Background(), 7).Return("seven", nil)
+			ctx, cancel := context.WithTimeout(context.WithValue(context.Background(), key("user"), "bob"), time.Hour)
+			defer cancel()
+			value, err := obj.Method5(ctx, 7)
+			reckon.That(value).Is.EqualTo("seven")
+			reckon.That(err).Is.Nil()
+			info := mock.GetCalls("Method5", ctx, 7).GetContext(0)
+			reckon.That(info.HasDeadline).Is.True()
+			reckon.That(info.Err).Is.Nil()
+			reckon.That(info.Values[key("user")]).Is.EqualTo("bob")
+			cancel()
+			_, err = obj.Method5(ctx, 7)
+			reckon.That(err).Is.EqualTo(context.Canceled)
+		})
+		suite.Describe("Variadic", func(suite *suiteshop.Suite) {
+			suite.Test("call shape", func(log *suiteshop.Log) {
+				mock := NewMockObject()
+				var obj Object = mock
+				mock.When("Method6", "nil", mockband.VarNil()).Return(0)
+				mock.When("Method6", "empty", mockband.WithVariadic()).Return(1)
+				mock.When("Method6", common.Any(), mockband.WithVariadic([]int{1, 2}, common.Any())).Return(2)
+				reckon.That(obj.Method6("nil")).Is.EqualTo(0)
+				reckon.That(func() { obj.Method6("nil", [][]int{}...) }).Will.Panic()
+				reckon.That(obj.Method6("empty")).Is.EqualTo(1)
+				reckon.That(obj.Method6("empty", [][]int{}...)).Is.EqualTo(1)
+				reckon.That(obj.Method6("pair", []int{1, 2}, []int{3})).Is.EqualTo(2)
+				reckon.That(func() { obj.Method6("pair", []int{1, 2}) }).Will.Panic()
+				params := mock.GetCalls("Method6", "empty", [][]int{}).GetParams(1)
+				reckon.That(params).Is.EqualTo(&common.Args{"empty", [][]int{}})
+				params = mock.GetCalls("Method6", "nil", [][]int(nil)).GetParams(0)
+				reckon.That(params).Is.EqualTo(&common.Args{"nil", [][]int(nil)})
+			})
+			suite.Test("tail matchers", func(log *suiteshop.Log) {
+				mock := NewMockObject()
+				var obj Object = mock
+				mock.When("Method6", "len", mockband.VarLen(2)).Return(2)
+				mock.When("Method6", "contains", mockband.WithVariadic(mockband.VarContains([]int{7}), mockband.VarLen(3))).Return(7)
+				reckon.That(obj.Method6("len", nil, nil)).Is.EqualTo(2)
+				reckon.That(func() { obj.Method6("len", nil) }).Will.Panic()
+				reckon.That(obj.Method6("contains", nil, []int{7}, nil)).Is.EqualTo(7)
+				reckon.That(func() { obj.Method6("contains", nil, []int{7}) }).Will.Panic()
+				reckon.That(func() { obj.Method6("contains", nil, nil, nil) }).Will.Panic()
+			})
+			suite.Test("CalledVarArg", func(log *suiteshop.Log) {
+				mock := NewMockObject()
+				var obj Object = mock
+				mock.When("Method3", mockband.VarNil()).Return("nil")
+				mock.When("Method3", mockband.WithVariadic()).Return("empty")
+				mock.When("Method3", "a", "b").Return("flat")
+				reckon.That(obj.Method3()).Is.EqualTo("nil")
+				reckon.That(obj.Method3([]string{}...)).Is.EqualTo("empty")
+				reckon.That(obj.Method3("a", "b")).Is.EqualTo("flat")
+				reckon.That(mock.CalledVarArg("Method3", nil).Get(0).String()).Is.EqualTo("nil")
+				params := mock.GetCalls("Method3", []string(nil)).GetParams(0)
+				reckon.That(params).Is.EqualTo(&common.Args{[]string(nil)})
+				params = mock.GetCalls("Method3", "a", "b").GetParams(0)
+				reckon.That(params).Is.EqualTo(&common.Args{"a", "b"})
+				nested := mockband.NewMock()
+				nested.When("Method6", "flat", []int{1, 2}).Return(1)
+				nested.When("Method6", "shaped", mockband.WithVariadic([]int{1, 2})).Return(2)
+				reckon.That(nested.CalledVarArg("Method6", "flat", [][]int{{1, 2}}).Get(0).Int()).Is.EqualTo(1)
+				reckon.That(nested.CalledVarArg("Method6", "shaped", [][]int{{1, 2}}).Get(0).Int()).Is.EqualTo(2)
+			})
+			suite.Test("not a slice", func(log *suiteshop.Log) {
+				reckon.That(func() {
+					mockband.NewMock().CalledVariadic("Method6", "value")
+				}).Will.PanicWith("Variadic parameter is not a slice: Method6")
+			})
+		})
+		suite.Test("CompareWith", func(log *suiteshop.Log) {
+			mock := NewMockObject()
+			var obj Object = mock
+			mock.CompareWith(common.Structural().IgnoreFields("Y"))
+			mock.When("Method4", &point{X: 1}).Return(nil)
+			reckon.That(obj.Method4(&point{X: 1, Y: 5})).Is.Nil()
+			reckon.That(func() { obj.Method4(&point{X: 2}) }).Will.Panic()
+		})
+		suite.Describe("Journal", func(suite *suiteshop.Suite) {
+			record := func() *MockObject {
+				mock := NewMockObject()
+				var obj Object = mock
+				mock.When("Method1", common.Any(), 5).Return()
+				mock.When("Method3").Return("First").Panic("Second")
+				obj.Method3()
+				obj.Method1("a", 5)
+				func() {
+					defer func() { recover() }()
+					obj.Method3()
+				}()
+				func() {
+					defer func() { recover() }()
+					obj.Method1("b", 6)
+				}()
+				return mock
+			}
+			suite.Test("text", func(log *suiteshop.Log) {
+				buffer := &bytes.Buffer{}
+				reckon.That(record().WriteJournal(buffer, mockband.JournalText)).Is.Nil()
+				reckon.That(buffer.String()).Is.EqualTo(strings.Join([]string{
+					"Stubs:",
+					"\tMethod1(<any>, 5) responses=1 sequence=cycle calls=1",
+					"\tMethod3() responses=2 sequence=cycle calls=2",
+					"Calls:",
+					"\t#1 Method3() -> (\"First\")",
+					"\t#2 Method1(\"a\", 5) -> ()",
+					"\t#3 Method3() panic: Second",
+					"\t#4 Method1(\"b\", 6) unmatched",
+				}, "\n") + "\n")
+			})
+			suite.Test("json", func(log *suiteshop.Log) {
+				buffer := &bytes.Buffer{}
+				reckon.That(record().WriteJournal(buffer, mockband.JournalJSON)).Is.Nil()
+				out := map[string][]map[string]interface{}{}
+				reckon.That(json.Unmarshal(buffer.Bytes(), &out)).Is.Nil()
+				reckon.That(len(out["stubs"])).Is.EqualTo(2)
+				reckon.That(len(out["calls"])).Is.EqualTo(4)
+				reckon.That(out["calls"][2]["panic"]).Is.EqualTo("Second")
+				reckon.That(out["calls"][3]["matched"]).Is.False()
+				reckon.That(record().WriteJournal(buffer, "xml")).Is.Not.Nil()
+			})
+			suite.Test("DumpOnFailure", func(log *suiteshop.Log) {
+				dir, err := ioutil.TempDir("", "journal")
+				reckon.That(err).Is.Nil()
+				defer os.RemoveAll(dir)
+				passed := &fakeTB{name: "TestPassed"}
+				mockband.DumpOnFailure(passed, dir, mockband.JournalText, record().Mock)
+				passed.finish()
+				failed := &fakeTB{name: "TestFailed/case one", failed: true}
+				mockband.DumpOnFailure(failed, dir, mockband.JournalJSON, record().Mock, record().Mock)
+				failed.finish()
+				files, _ := ioutil.ReadDir(dir)
+				names := []string{}
+				for _, file := range files {
+					names = append(names, file.Name())
+				}
+				reckon.That(names).Is.EqualTo([]string{"TestFailed_case_one-0.json", "TestFailed_case_one-1.json"})
+			})
+		})
+		suite.Describe("On", func(suite *suiteshop.Suite) {
+			suite.Test("method expressions", func(log *suiteshop.Log) {
+				mock := NewMockObject()
+				var obj Object = mock
+				reckon.That(mockband.On(mock.Mock, Object.Method2).Name()).Is.EqualTo("Method2")
+				reckon.That(mockband.On(mock.Mock, (*MockObject).Method1).Name()).Is.EqualTo("Method1")
+				reckon.That(mockband.On(mock.Mock, obj.Method3).Name()).Is.EqualTo("Method3")
+				mockband.On(mock.Mock, Object.Method2).With().Return("value", 3, nil)
+				mockband.On(mock.Mock, Object.Method5).With(common.Any(), 7).Return("seven", nil)
+				mockband.On(mock.Mock, Object.Method6).With("tail", mockband.VarLen(1)).Return(1)
+				str, num, err := obj.Method2()
+				reckon.That(str).Is.EqualTo("value")
+				reckon.That(num).Is.EqualTo(3)
+				reckon.That(err).Is.Nil()
+				value, _ := obj.Method5(context.Background(), 7)
+				reckon.That(value).Is.EqualTo("seven")
+				reckon.That(obj.Method6("tail", nil)).Is.EqualTo(1)
+				reckon.That(mock.HasCalled("Method2").Once()).Is.True()
+			})
+			suite.Test("type checks", func(log *suiteshop.Log) {
+				mock := NewMockObject()
+				reckon.That(func() {
+					mockband.On(mock.Mock, Object.Method1).With(5, 5)
+				}).Will.PanicWith("Parameter 0 of Method1: int is not assignable to string")
+				reckon.That(func() {
+					mockband.On(mock.Mock, Object.Method1).With("a", 5, 6)
+				}).Will.PanicWith("Too many parameters for Method1: 3 > 2")
+				reckon.That(func() {
+					mockband.On(mock.Mock, Object.Method2).With().Return("a", 1)
+				}).Will.PanicWith("Wrong number of return values for Method2: 2 != 3")
+				reckon.That(func() {
+					mockband.On(mock.Mock, Object.Method2).With().Return("a", nil, nil)
+				}).Will.PanicWith("Return value 1 of Method2: nil is not assignable to int")
+				reckon.That(func() {
+					mockband.On(mock.Mock, "Method2")
+				}).Will.PanicWith("Method expression is not a function")
+			})
+		})
+		suite.Describe("Do", func(suite *suiteshop.Suite) {
+			suite.Test("typed callbacks", func(log *suiteshop.Log) {
+				mock := NewMockObject()
+				var obj Object = mock
+				mockband.Do(mock.When("Method5", common.Any(), common.Any()), func(ctx context.Context, id int) (string, error) {
+					if id < 0 {
+						return "", fmt.Errorf("bad id: %v", id)
+					}
+					return fmt.Sprintf("user-%v", id), nil
+				})
+				mockband.Do(mock.When("Method6", "count", common.Any()), func(prefix string, groups ...[]int) int {
+					return len(groups)
+				})
+				mockband.Do(mock.When("Method3", common.Any(), common.Any()), func(params ...string) interface{} {
+					return strings.Join(params, "+")
+				})
+				value, err := obj.Method5(context.Background(), 4)
+				reckon.That(value).Is.EqualTo("user-4")
+				reckon.That(err).Is.Nil()
+				_, err = obj.Method5(context.Background(), -1)
+				reckon.That(err.Error()).Is.EqualTo("bad id: -1")
+				reckon.That(obj.Method6("count", []int{1}, []int{2})).Is.EqualTo(2)
+				reckon.That(obj.Method3("a", "b")).Is.EqualTo("a+b")
+			})
+			suite.Test("signature checks", func(log *suiteshop.Log) {
+				mock := NewMockObject()
+				var obj Object = mock
+				mockband.Do(mock.When("Method5", common.Any(), common.Any()), func(id int) (string, error) {
+					return "", nil
+				})
+				reckon.That(func() {
+					obj.Method5(context.Background(), 1)
+				}).Will.PanicWith("Callback expects 1 parameters, got 2")
+				mockband.Do(mock.When("Method1", common.Any(), common.Any()), func(a, b string) {})
+				reckon.That(func() {
+					obj.Method1("a", 1)
+				}).Will.PanicWith("Callback parameter 1: int is not assignable to string")
+				reckon.That(func() {
+					mockband.Do(mock.When("Method2"), "not a function")
+				}).Will.PanicWith("Callback is not a function")
+			})
+			suite.Test("typed stubs", func(log *suiteshop.Log) {
+				mock := NewMockObject()
+				var obj Object = mock
+				mockband.On(mock.Mock, Object.Method5).With(common.Any(), common.Any()).Do(func(ctx context.Context, id int) (string, error) {
+					return fmt.Sprint(id * 2), nil
+				})
+				value, _ := obj.Method5(context.Background(), 21)
+				reckon.That(value).Is.EqualTo("42")
+				reckon.That(func() {
+					mockband.On(mock.Mock, Object.Method5).With().Do(func(ctx context.Context, id string) (string, error) {
+						return id, nil
+					})
+				}).Will.PanicWith("Callback parameter 1 of Method5: int is not assignable to string")
+				reckon.That(func() {
+					mockband.On(mock.Mock, Object.Method5).With().Do(func(ctx context.Context, id int) string {
+						return ""
+					})
+				}).Will.PanicWith("Wrong number of return values for Method5: 1 != 2")
+				reckon.That(func() {
+					mockband.On(mock.Mock, Object.Method5).With().Do(func(id int) (string, error) {
+						return "", nil
+					})
+				}).Will.PanicWith("Callback for Method5 has 1 parameters, expected 2")
+			})
+		})
+		suite.Test("Coverage", func(log *suiteshop.Log) {
+			mockband.ResetCoverage()
+			mock := &MockObject{mockband.NewMock().Named("coverage").Implements((*Object)(nil))}
+			var obj Object = mock
+			unregistered := NewMockObject()
+			unregistered.When("Method1", "c", 3).Return()
+			unregistered.Method1("c", 3)
+			mock.When("Method1", "a", 1).Return()
+			mock.When("Method1", "b", 2).Return()
+			mock.When("Method5", common.Any(), common.Any()).Return("", nil)
+			obj.Method1("a", 1)
+			obj.Method1("a", 1)
+			reckon.That(func() { obj.Method2() }).Will.PanicWith("Function with param signature not found: Method2")
+			lines := []string{}
+			for _, line := range mockband.Coverage() {
+				reckon.That(line.Interface).Is.EqualTo("mockband_test.Object (coverage)")
+				lines = append(lines, line.String())
+			}
+			reckon.That(lines).Is.EqualTo([]string{
+				"Method1 stubs=2 hit=1 calls=2",
+				"Method2 never stubbed calls=1",
+				"Method3 never stubbed or called",
+				"Method4 never stubbed or called",
+				"Method5 never called stubs=1",
+				"Method6 never stubbed or called",
+			})
+			buffer := &bytes.Buffer{}
+			reckon.That(mockband.WriteCoverage(buffer)).Is.Nil()
+			reckon.That(buffer.String()).Does.Contain("\tmockband_test.Object (coverage):\n\t\tMethod1 stubs=2 hit=1 calls=2\n")
+			mockband.ResetCoverage()
+			reckon.That(mockband.Coverage()).Is.EqualTo([]mockband.CoverageLine{})
+			reckon.That(func() {
+				mockband.NewMock().Implements(Object(nil))
+			}).Will.PanicWith("Implements requires a nil interface pointer, e.g. (*Iface)(nil)")
+		})
+		suite.Test("ReportTo", func(log *suiteshop.Log) {
+			sink := mockband.NewSink()
+			mock := NewMockObject()
+			mock.ReportTo(sink)
+			var obj Object = mock
+			mock.When("Method2").Return("once", 1, nil).Exhaust()
+			done := make(chan struct{})
+			var text string
+			var count int
+			var err error
+			go func() {
+				defer close(done)
+				obj.Method1("worker", 1)
+				obj.Method2()
+				text, count, err = obj.Method2()
+			}()
+			<-done
+			reckon.That(text).Is.EqualTo("")
+			reckon.That(count).Is.EqualTo(0)
+			reckon.That(err).Is.Nil()
+			reckon.That(sink.Failures()).Is.EqualTo([]string{
+				"Function with param signature not found: Method1",
+				"Function responses exhausted: Method2",
+			})
+			reporter := &fakeTB{name: "TestReportTo"}
+			sink.Check(reporter)
+			reckon.That(reporter.errors).Is.EqualTo([]string{
+				"mock failure: Function with param signature not found: Method1",
+				"mock failure: Function responses exhausted: Method2",
+			})
+			reckon.That(sink.Err()).Is.Nil()
+			obj.Method1("again", 2)
+			reckon.That(sink.Err().Error()).Is.EqualTo("Function with param signature not found: Method1")
+			reckon.That(mock.HasCalled("Method2").Twice()).Is.True()
+		})
+		suite.Test("ReportTo unstubbed results", func(log *suiteshop.Log) {
+			sink := mockband.NewSink()
+			bare := mockband.NewMock().ReportTo(sink)
+			typed := NewMockObject()
+			typed.Implements((*Object)(nil)).ReportTo(sink)
+			var obj Object = typed
+			var ready bool
+			var count int
+			var label string
+			var text string
+			var number int
+			var err error
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				args := bare.Called("IsReady")
+				ready = args.Get(0).Bool()
+				count = args.Get(1).Int()
+				label = args.Get(2).String()
+				text, number, err = obj.Method2()
+			}()
+			<-done
+			reckon.That(ready).Is.False()
+			reckon.That(count).Is.EqualTo(0)
+			reckon.That(label).Is.EqualTo("")
+			reckon.That(text).Is.EqualTo("")
+			reckon.That(number).Is.EqualTo(0)
+			reckon.That(err).Is.Nil()
+			reckon.That(sink.Failures()).Is.EqualTo([]string{
+				"Function with param signature not found: IsReady",
+				"Function with param signature not found: Method2",
+			})
+		})
+		suite.Test("concurrent calls", func(log *suiteshop.Log) {
+			sink := mockband.NewSink()
+			mock := NewMockObject()
+			mock.ReportTo(sink)
+			var obj Object = mock
+			mock.When("Method2").Return("shared", 1, nil)
+			var wait sync.WaitGroup
+			for worker := 0; worker < 8; worker++ {
+				wait.Add(1)
+				go func(worker int) {
+					defer wait.Done()
+					for index := 0; index < 50; index++ {
+						obj.Method2()
+						obj.Method1("worker", worker)
+						mock.When("Method4", worker).Return(nil)
+						obj.Method4(worker)
+						mock.HasCalled("Method2")
+					}
+				}(worker)
+			}
+			wait.Wait()
+			reckon.That(mock.HasCalled("Method2").Times(400)).Is.True()
+			reckon.That(mock.HasCalled("Method4", 3).Times(50)).Is.True()
+			reckon.That(len(sink.Failures())).Is.EqualTo(400)
+			reckon.That(mockband.Coverage()).Is.Not.Nil()
+		})
+		suite.Describe("Chan", func(suite *suiteshop.Suite) {
+			suite.Test("records sends", func(log *suiteshop.Log) {
+				tb := &fakeTB{name: "TestChan"}
+				defer tb.finish()
+				ch := mockband.Chan[string](tb)
+				go func() {
+					ch.In() <- "created"
+					ch.In() <- "updated"
+				}()
+				reckon.That(ch.SentExactly(time.Second, "created", "updated")).Is.True()
+				reckon.That(ch.Sent()).Is.EqualTo([]string{"created", "updated"})
+				reckon.That(ch.SentExactly(10*time.Millisecond, "created")).Is.False()
+				close(ch.In())
+				reckon.That(ch.WaitClosed(time.Second)).Is.True()
+				reckon.That(ch.Closed()).Is.True()
+				reckon.That(ch.SentExactly(10*time.Millisecond, "created", "updated")).Is.True()
+			})
+			suite.Test("scripted deliveries", func(log *suiteshop.Log) {
+				tb := &fakeTB{name: "TestChan"}
+				defer tb.finish()
+				ch := mockband.Chan[int](tb).Deliver(1, 2).CloseAfter(3)
+				reckon.That(<-ch.Out()).Is.EqualTo(1)
+				reckon.That(<-ch.Out()).Is.EqualTo(2)
+				ch.Deliver(3, 4)
+				received := []int{}
+				for value := range ch.Out() {
+					received = append(received, value)
+				}
+				reckon.That(received).Is.EqualTo([]int{3})
+				reckon.That(ch.Delivered()).Is.EqualTo(3)
+			})
+			suite.Test("blocking", func(log *suiteshop.Log) {
+				tb := &fakeTB{name: "TestChan"}
+				defer tb.finish()
+				ch := mockband.Chan[int](tb).Block()
+				select {
+				case ch.In() <- 1:
+					panic("send should block")
+				case <-time.After(10 * time.Millisecond):
+				}
+				ch.Deliver(5)
+				select {
+				case <-ch.Out():
+					panic("delivery should block")
+				case <-time.After(10 * time.Millisecond):
+				}
+				ch.Unblock()
+				ch.In() <- 2
+				reckon.That(<-ch.Out()).Is.EqualTo(5)
+				reckon.That(ch.SentExactly(time.Second, 2)).Is.True()
+			})
+			suite.Test("cleanup", func(log *suiteshop.Log) {
+				tb := &fakeTB{name: "TestChan"}
+				ch := mockband.Chan[int](tb)
+				tb.finish()
+				select {
+				case _, ok := <-ch.Out():
+					reckon.That(ok).Is.False()
+				case <-time.After(time.Second):
+					panic("cleanup should stop the double")
+				}
+			})
+		})
 	}).Post(func(message string) {
 		list = append(list, message)
 	})
@@ -163,6 +757,48 @@ type Object interface {
 	Method2() (string, int, error)
 	Method3(params ...string) interface{}
 	Method4(pointer interface{}) error
+	Method5(ctx context.Context, id int) (string, error)
+	Method6(prefix string, groups ...[]int) int
+}
+
+type fakeTB struct {
+	name     string
+	failed   bool
+	cleanups []func()
+	errors   []string
+}
+
+func (t *fakeTB) Helper() {
+}
+
+func (t *fakeTB) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func (t *fakeTB) Name() string {
+	return t.name
+}
+
+func (t *fakeTB) Failed() bool {
+	return t.failed
+}
+
+func (t *fakeTB) Cleanup(fn func()) {
+	t.cleanups = append(t.cleanups, fn)
+}
+
+func (t *fakeTB) Logf(format string, args ...interface{}) {
+}
+
+func (t *fakeTB) finish() {
+	for _, fn := range t.cleanups {
+		fn()
+	}
+}
+
+type point struct {
+	X int
+	Y int
 }
 
 type MockObject struct {
@@ -173,6 +809,11 @@ func NewMockObject() *MockObject {
 	return &MockObject{mockband.NewMock()}
 }
 
+func (o *MockObject) RecordContextKeys(keys ...interface{}) *MockObject {
+	o.Mock.RecordContextKeys(keys...)
+	return o
+}
+
 func (o *MockObject) Method1(arg1 string, arg2 int) {
 	o.Mock.Called("Method1", arg1, arg2)
 }
@@ -191,3 +832,13 @@ func (o *MockObject) Method4(pointer interface{}) error {
 	args := o.Mock.Called("Method4", pointer)
 	return args.Get(0).Error()
 }
+
+func (o *MockObject) Method5(ctx context.Context, id int) (string, error) {
+	args := o.Mock.Called("Method5", ctx, id)
+	return args.Get(0).String(), args.Get(1).Error()
+}
+
+func (o *MockObject) Method6(prefix string, groups ...[]int) int {
+	args := o.Mock.CalledVariadic("Method6", prefix, groups)
+	return args.Get(0).Int()
+}
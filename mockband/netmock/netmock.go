@@ -0,0 +1,245 @@
+package netmock
+
+import (
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+)
+
+type Addr string
+
+func (a Addr) Network() string {
+	return "mock"
+}
+
+func (a Addr) String() string {
+	return string(a)
+}
+
+type Packet struct {
+	Op   string
+	Data []byte
+}
+
+type Conn struct {
+	local      Addr
+	remote     Addr
+	in         *pipe
+	out        *pipe
+	mu         sync.Mutex
+	readLimit  int
+	writeLimit int
+	deadline   time.Time
+	traffic    []Packet
+	closed     bool
+}
+
+func Pipe() (*Conn, *Conn) {
+	return newPair("client", "server")
+}
+
+func newPair(client, server Addr) (*Conn, *Conn) {
+	up := newPipe()
+	down := newPipe()
+	return &Conn{local: client, remote: server, in: down, out: up},
+		&Conn{local: server, remote: client, in: up, out: down}
+}
+
+func (c *Conn) Read(b []byte) (int, error) {
+	if c.isClosed() {
+		return 0, c.opError("read", net.ErrClosed)
+	}
+	c.mu.Lock()
+	limit := c.readLimit
+	c.mu.Unlock()
+	n, err := c.in.read(b, limit)
+	c.record("read", b[:n])
+	if err != nil && err != io.EOF {
+		return n, c.opError("read", err)
+	}
+	return n, err
+}
+
+func (c *Conn) Write(b []byte) (int, error) {
+	if c.isClosed() {
+		return 0, c.opError("write", net.ErrClosed)
+	}
+	c.mu.Lock()
+	limit := c.writeLimit
+	deadline := c.deadline
+	c.mu.Unlock()
+	if !deadline.IsZero() && !time.Now().Before(deadline) {
+		return 0, c.opError("write", os.ErrDeadlineExceeded)
+	}
+	size := len(b)
+	if limit > 0 && limit < size {
+		size = limit
+	}
+	if err := c.out.write(b[:size]); err != nil {
+		return 0, c.opError("write", err)
+	}
+	c.record("write", b[:size])
+	if size < len(b) {
+		return size, io.ErrShortWrite
+	}
+	return size, nil
+}
+
+func (c *Conn) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return c.opError("close", net.ErrClosed)
+	}
+	c.closed = true
+	c.out.close()
+	c.in.fail(io.ErrClosedPipe)
+	return nil
+}
+
+func (c *Conn) Reset() {
+	c.in.fail(syscall.ECONNRESET)
+	c.out.fail(syscall.ECONNRESET)
+}
+
+func (c *Conn) LocalAddr() net.Addr {
+	return c.local
+}
+
+func (c *Conn) RemoteAddr() net.Addr {
+	return c.remote
+}
+
+func (c *Conn) SetDeadline(t time.Time) error {
+	c.SetReadDeadline(t)
+	return c.SetWriteDeadline(t)
+}
+
+func (c *Conn) SetReadDeadline(t time.Time) error {
+	c.in.setDeadline(t)
+	return nil
+}
+
+func (c *Conn) SetWriteDeadline(t time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.deadline = t
+	return nil
+}
+
+func (c *Conn) LimitReads(size int) *Conn {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.readLimit = size
+	return c
+}
+
+func (c *Conn) LimitWrites(size int) *Conn {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.writeLimit = size
+	return c
+}
+
+func (c *Conn) Traffic() []Packet {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Packet{}, c.traffic...)
+}
+
+func (c *Conn) Sent() []byte {
+	return c.collect("write")
+}
+
+func (c *Conn) Received() []byte {
+	return c.collect("read")
+}
+
+func (c *Conn) collect(op string) []byte {
+	out := []byte{}
+	for _, packet := range c.Traffic() {
+		if packet.Op == op {
+			out = append(out, packet.Data...)
+		}
+	}
+	return out
+}
+
+func (c *Conn) record(op string, data []byte) {
+	if len(data) == 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.traffic = append(c.traffic, Packet{op, append([]byte{}, data...)})
+}
+
+func (c *Conn) isClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+func (c *Conn) opError(op string, err error) error {
+	return &net.OpError{Op: op, Net: "mock", Source: c.local, Addr: c.remote, Err: err}
+}
+
+type Listener struct {
+	addr  Addr
+	conns chan *Conn
+	done  chan struct{}
+	once  sync.Once
+	count int
+	mu    sync.Mutex
+}
+
+func Listen(addr string) *Listener {
+	return &Listener{
+		addr:  Addr(addr),
+		conns: make(chan *Conn, 64),
+		done:  make(chan struct{}),
+	}
+}
+
+func (l *Listener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.done:
+		return nil, &net.OpError{Op: "accept", Net: "mock", Addr: l.addr, Err: net.ErrClosed}
+	}
+}
+
+func (l *Listener) Close() error {
+	l.once.Do(func() {
+		close(l.done)
+	})
+	return nil
+}
+
+func (l *Listener) Addr() net.Addr {
+	return l.addr
+}
+
+func (l *Listener) Dial() (*Conn, error) {
+	l.mu.Lock()
+	l.count++
+	client := Addr(l.addr.String() + "-client-" + strconv.Itoa(l.count))
+	l.mu.Unlock()
+	local, remote := newPair(client, l.addr)
+	select {
+	case <-l.done:
+		return nil, &net.OpError{Op: "dial", Net: "mock", Addr: l.addr, Err: syscall.ECONNREFUSED}
+	default:
+	}
+	select {
+	case l.conns <- remote:
+		return local, nil
+	case <-l.done:
+		return nil, &net.OpError{Op: "dial", Net: "mock", Addr: l.addr, Err: syscall.ECONNREFUSED}
+	}
+}
@@ -0,0 +1,150 @@
+package netmock_test
+
+import (
+	"."
+
+	"../../common"
+	"../../reckon"
+	"../../suiteshop"
+
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func Test(t *testing.T) {
+	list := []string{}
+	hasErrors := suiteshop.Describe("NetMock", func(suite *suiteshop.Suite) {
+		suite.Describe("Conn", func(suite *suiteshop.Suite) {
+			suite.Test("pipe", func(log *suiteshop.Log) {
+				client, server := netmock.Pipe()
+				n, err := client.Write([]byte("hello"))
+				reckon.That(n).Is.EqualTo(5)
+				reckon.That(err).Is.Nil()
+				buffer := make([]byte, 16)
+				n, err = server.Read(buffer)
+				reckon.That(string(buffer[:n])).Is.EqualTo("hello")
+				reckon.That(err).Is.Nil()
+				reckon.That(client.Sent()).Is.EqualTo([]byte("hello"))
+				reckon.That(server.Received()).Is.EqualTo([]byte("hello"))
+				reckon.That(server.Traffic()).Is.EqualTo([]netmock.Packet{{"read", []byte("hello")}})
+			})
+			suite.Test("close", func(log *suiteshop.Log) {
+				client, server := netmock.Pipe()
+				client.Write([]byte("bye"))
+				reckon.That(client.Close()).Is.Nil()
+				buffer := make([]byte, 16)
+				n, err := server.Read(buffer)
+				reckon.That(string(buffer[:n])).Is.EqualTo("bye")
+				_, err = server.Read(buffer)
+				reckon.That(err).Is.EqualTo(io.EOF)
+				_, err = client.Read(buffer)
+				reckon.That(errors.Is(err, net.ErrClosed)).Is.True()
+			})
+			suite.Test("deadline", func(log *suiteshop.Log) {
+				client, _ := netmock.Pipe()
+				client.SetReadDeadline(time.Now().Add(10 * time.Millisecond))
+				_, err := client.Read(make([]byte, 16))
+				reckon.That(errors.Is(err, os.ErrDeadlineExceeded)).Is.True()
+				netErr, ok := err.(net.Error)
+				reckon.That(ok && netErr.Timeout()).Is.True()
+				client.SetWriteDeadline(time.Now().Add(-time.Second))
+				_, err = client.Write([]byte("late"))
+				reckon.That(errors.Is(err, os.ErrDeadlineExceeded)).Is.True()
+			})
+			suite.Test("partial reads and writes", func(log *suiteshop.Log) {
+				client, server := netmock.Pipe()
+				client.LimitWrites(3)
+				server.LimitReads(2)
+				n, err := client.Write([]byte("abcdef"))
+				reckon.That(n).Is.EqualTo(3)
+				reckon.That(err).Is.EqualTo(io.ErrShortWrite)
+				buffer := make([]byte, 16)
+				n, _ = server.Read(buffer)
+				reckon.That(string(buffer[:n])).Is.EqualTo("ab")
+				n, _ = server.Read(buffer)
+				reckon.That(string(buffer[:n])).Is.EqualTo("c")
+			})
+			suite.Test("reset", func(log *suiteshop.Log) {
+				client, server := netmock.Pipe()
+				server.Reset()
+				_, err := client.Read(make([]byte, 16))
+				reckon.That(errors.Is(err, syscall.ECONNRESET)).Is.True()
+				_, err = client.Write([]byte("x"))
+				reckon.That(errors.Is(err, syscall.ECONNRESET)).Is.True()
+			})
+		})
+		suite.Describe("Listener", func(suite *suiteshop.Suite) {
+			suite.Test("dial and accept", func(log *suiteshop.Log) {
+				listener := netmock.Listen("mock:25")
+				client, err := listener.Dial()
+				reckon.That(err).Is.Nil()
+				server, err := listener.Accept()
+				reckon.That(err).Is.Nil()
+				reckon.That(server.LocalAddr().String()).Is.EqualTo("mock:25")
+				reckon.That(server.RemoteAddr()).Is.EqualTo(client.LocalAddr())
+				listener.Close()
+				_, err = listener.Accept()
+				reckon.That(errors.Is(err, net.ErrClosed)).Is.True()
+				_, err = listener.Dial()
+				reckon.That(errors.Is(err, syscall.ECONNREFUSED)).Is.True()
+			})
+		})
+		suite.Describe("Peer", func(suite *suiteshop.Suite) {
+			suite.Test("scripted replies", func(log *suiteshop.Log) {
+				client, server := netmock.Pipe()
+				peer := netmock.NewPeer().
+					Greet([]byte("220 ready\r\n")).
+					Expect([]byte("HELO\r\n"), "250 hello\r\n").
+					Expect([]byte("QUIT\r\n"), netmock.Close)
+				peer.Start(server)
+				buffer := make([]byte, 64)
+				n, _ := client.Read(buffer)
+				reckon.That(string(buffer[:n])).Is.EqualTo("220 ready\r\n")
+				client.Write([]byte("HELO\r\n"))
+				n, _ = client.Read(buffer)
+				reckon.That(string(buffer[:n])).Is.EqualTo("250 hello\r\n")
+				client.Write([]byte("QUIT\r\n"))
+				_, err := client.Read(buffer)
+				reckon.That(err).Is.EqualTo(io.EOF)
+				reckon.That(peer.Wait()).Is.Nil()
+				reckon.That(peer.HasCalled("Receive", []byte("HELO\r\n")).Once()).Is.True()
+			})
+			suite.Test("matchers and resets", func(log *suiteshop.Log) {
+				client, server := netmock.Pipe()
+				peer := netmock.NewPeer()
+				peer.When("Receive", common.Regex("^PING")).Return("PONG").Return(netmock.Reset)
+				peer.Start(server)
+				buffer := make([]byte, 64)
+				client.Write([]byte("PING 1"))
+				n, _ := client.Read(buffer)
+				reckon.That(string(buffer[:n])).Is.EqualTo("PONG")
+				client.Write([]byte("PING 2"))
+				_, err := client.Read(buffer)
+				reckon.That(errors.Is(err, syscall.ECONNRESET)).Is.True()
+				reckon.That(peer.Wait()).Is.Nil()
+			})
+			suite.Test("unexpected data", func(log *suiteshop.Log) {
+				client, server := netmock.Pipe()
+				peer := netmock.NewPeer().Start(server)
+				client.Write([]byte("???"))
+				_, err := client.Read(make([]byte, 16))
+				reckon.That(errors.Is(err, syscall.ECONNRESET)).Is.True()
+				reckon.That(peer.Wait()).Is.Not.Nil()
+			})
+		})
+	}).Post(func(message string) {
+		list = append(list, message)
+	})
+	if hasErrors {
+		t.Fatal(strings.Join(list, "\n"))
+	} else {
+		fmt.Println(strings.Join(list, "\n"))
+	}
+}
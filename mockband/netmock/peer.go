@@ -0,0 +1,112 @@
+package netmock
+
+import (
+	".."
+	"fmt"
+	"io"
+	"net"
+)
+
+type action string
+
+var (
+	Reset = action("reset")
+	Close = action("close")
+)
+
+type Peer struct {
+	*mockband.Mock
+	greeting []byte
+	done     chan struct{}
+	err      error
+}
+
+func NewPeer() *Peer {
+	return &Peer{Mock: mockband.NewMock()}
+}
+
+func (p *Peer) Greet(data []byte) *Peer {
+	p.greeting = data
+	return p
+}
+
+func (p *Peer) Expect(data []byte, reply interface{}) *Peer {
+	p.When("Receive", data).Return(reply)
+	return p
+}
+
+func (p *Peer) Start(conn net.Conn) *Peer {
+	p.done = make(chan struct{})
+	go func() {
+		defer close(p.done)
+		p.err = p.Serve(conn)
+	}()
+	return p
+}
+
+func (p *Peer) Wait() error {
+	if p.done != nil {
+		<-p.done
+	}
+	return p.err
+}
+
+func (p *Peer) Serve(conn net.Conn) error {
+	if len(p.greeting) > 0 {
+		if _, err := conn.Write(p.greeting); err != nil {
+			return err
+		}
+	}
+	buffer := make([]byte, 64*1024)
+	for {
+		n, err := conn.Read(buffer)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		data := append([]byte{}, buffer[:n]...)
+		reply, err := p.receive(data)
+		if err != nil {
+			reset(conn)
+			return err
+		}
+		switch value := reply.(type) {
+		case nil:
+		case action:
+			if value == Reset {
+				reset(conn)
+			} else {
+				conn.Close()
+			}
+			return nil
+		case string:
+			_, err = conn.Write([]byte(value))
+		case []byte:
+			_, err = conn.Write(value)
+		default:
+			err = fmt.Errorf("unsupported reply: %v", value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (p *Peer) receive(data []byte) (reply interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("unexpected data %q: %v", data, r)
+		}
+	}()
+	return p.Called("Receive", data).Get(0).Elem(), nil
+}
+
+func reset(conn net.Conn) {
+	if c, ok := conn.(*Conn); ok {
+		c.Reset()
+	} else {
+		conn.Close()
+	}
+}
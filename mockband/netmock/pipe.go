@@ -0,0 +1,114 @@
+package netmock
+
+import (
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+type pipe struct {
+	mu       sync.Mutex
+	chunks   [][]byte
+	closed   bool
+	err      error
+	deadline time.Time
+	wake     chan struct{}
+}
+
+func newPipe() *pipe {
+	return &pipe{wake: make(chan struct{})}
+}
+
+func (p *pipe) signal() {
+	close(p.wake)
+	p.wake = make(chan struct{})
+}
+
+func (p *pipe) write(data []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil {
+		return p.err
+	}
+	if p.closed {
+		return io.ErrClosedPipe
+	}
+	p.chunks = append(p.chunks, append([]byte{}, data...))
+	p.signal()
+	return nil
+}
+
+func (p *pipe) read(b []byte, limit int) (int, error) {
+	for {
+		p.mu.Lock()
+		if p.err != nil {
+			p.mu.Unlock()
+			return 0, p.err
+		}
+		if len(p.chunks) > 0 {
+			n := p.take(b, limit)
+			p.mu.Unlock()
+			return n, nil
+		}
+		if p.closed {
+			p.mu.Unlock()
+			return 0, io.EOF
+		}
+		deadline := p.deadline
+		wake := p.wake
+		p.mu.Unlock()
+		if deadline.IsZero() {
+			<-wake
+			continue
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return 0, os.ErrDeadlineExceeded
+		}
+		timer := time.NewTimer(remaining)
+		select {
+		case <-wake:
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+}
+
+func (p *pipe) take(b []byte, limit int) int {
+	chunk := p.chunks[0]
+	size := len(b)
+	if limit > 0 && limit < size {
+		size = limit
+	}
+	n := copy(b[:size], chunk)
+	if n == len(chunk) {
+		p.chunks = p.chunks[1:]
+	} else {
+		p.chunks[0] = chunk[n:]
+	}
+	return n
+}
+
+func (p *pipe) setDeadline(t time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.deadline = t
+	p.signal()
+}
+
+func (p *pipe) close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closed = true
+	p.signal()
+}
+
+func (p *pipe) fail(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err == nil {
+		p.err = err
+	}
+	p.signal()
+}
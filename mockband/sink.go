@@ -0,0 +1,58 @@
+package mockband
+
+import (
+	"errors"
+	"strings"
+	"sync"
+)
+
+type Sink struct {
+	mu       sync.Mutex
+	failures []string
+}
+
+func NewSink() *Sink {
+	return &Sink{failures: []string{}}
+}
+
+func (m *Mock) ReportTo(sink *Sink) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sink = sink
+	return m
+}
+
+func (s *Sink) Record(message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, message)
+}
+
+func (s *Sink) Failures() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.failures...)
+}
+
+func (s *Sink) Err() error {
+	failures := s.drain()
+	if len(failures) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(failures, "\n"))
+}
+
+func (s *Sink) Check(t TB) {
+	t.Helper()
+	for _, failure := range s.drain() {
+		t.Errorf("mock failure: %v", failure)
+	}
+}
+
+func (s *Sink) drain() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	failures := s.failures
+	s.failures = []string{}
+	return failures
+}
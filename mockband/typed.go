@@ -0,0 +1,119 @@
+package mockband
+
+import (
+	"../common"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+)
+
+func On[F interface{}](mock *Mock, method F) *typedMethod[F] {
+	value := reflect.ValueOf(method)
+	if value.Kind() != reflect.Func || value.IsNil() {
+		panic("Method expression is not a function")
+	}
+	full := runtime.FuncForPC(value.Pointer()).Name()
+	offset := 1
+	if strings.HasSuffix(full, "-fm") {
+		full = strings.TrimSuffix(full, "-fm")
+		offset = 0
+	}
+	name := full[strings.LastIndex(full, ".")+1:]
+	signature := value.Type()
+	if signature.NumIn() < offset {
+		panic("Method expression has no receiver: " + name)
+	}
+	return &typedMethod[F]{mock, name, signature, offset}
+}
+
+type typedMethod[F interface{}] struct {
+	mock      *Mock
+	name      string
+	signature reflect.Type
+	offset    int
+}
+
+func (t *typedMethod[F]) Name() string {
+	return t.name
+}
+
+func (t *typedMethod[F]) With(params ...interface{}) *typedStub[F] {
+	count := t.signature.NumIn() - t.offset
+	if len(params) > count {
+		panic(fmt.Sprintf("Too many parameters for %v: %v > %v", t.name, len(params), count))
+	}
+	for index, param := range params {
+		expected := t.signature.In(t.offset + index)
+		if t.signature.IsVariadic() && index == count-1 {
+			if _, ok := param.(common.Matcher); ok {
+				continue
+			}
+		}
+		checkAssignable(param, expected, fmt.Sprintf("Parameter %v of %v", index, t.name))
+	}
+	return &typedStub[F]{t.mock.When(t.name, params...), t}
+}
+
+type typedStub[F interface{}] struct {
+	call   *call
+	method *typedMethod[F]
+}
+
+func (t *typedStub[F]) Return(values ...interface{}) *typedStub[F] {
+	signature := t.method.signature
+	if len(values) != signature.NumOut() {
+		panic(fmt.Sprintf("Wrong number of return values for %v: %v != %v", t.method.name, len(values), signature.NumOut()))
+	}
+	for index, value := range values {
+		checkAssignable(value, signature.Out(index), fmt.Sprintf("Return value %v of %v", index, t.method.name))
+	}
+	t.call.Return(values...)
+	return t
+}
+
+func (t *typedStub[F]) Panic(err interface{}) *typedStub[F] {
+	t.call.Panic(err)
+	return t
+}
+
+func (t *typedStub[F]) Then(fn func(args *common.Args) *common.Args) *typedStub[F] {
+	t.call.Then(fn)
+	return t
+}
+
+func (t *typedStub[F]) Delay(d time.Duration) *typedStub[F] {
+	t.call.Delay(d)
+	return t
+}
+
+func (t *typedStub[F]) RepeatLast() *typedStub[F] {
+	t.call.RepeatLast()
+	return t
+}
+
+func (t *typedStub[F]) Exhaust() *typedStub[F] {
+	t.call.Exhaust()
+	return t
+}
+
+func checkAssignable(value interface{}, expected reflect.Type, label string) {
+	if reflect.DeepEqual(value, common.Any()) {
+		return
+	}
+	if _, ok := value.(common.Matcher); ok {
+		return
+	}
+	if value == nil {
+		switch expected.Kind() {
+		case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+			return
+		}
+		panic(fmt.Sprintf("%v: nil is not assignable to %v", label, expected))
+	}
+	actual := reflect.TypeOf(value)
+	if !actual.AssignableTo(expected) {
+		panic(fmt.Sprintf("%v: %v is not assignable to %v", label, actual, expected))
+	}
+}
@@ -0,0 +1,140 @@
+package mockband
+
+import (
+	"../common"
+	"reflect"
+)
+
+func (m *Mock) CalledVariadic(name string, params ...interface{}) *common.Args {
+	if len(params) == 0 {
+		panic("Variadic parameter missing: " + name)
+	}
+	kind := reflect.ValueOf(params[len(params)-1]).Kind()
+	if kind != reflect.Slice {
+		panic("Variadic parameter is not a slice: " + name)
+	}
+	return m.Called(name, params...)
+}
+
+func flatten(params []interface{}) []interface{} {
+	args := common.Args(append([]interface{}{}, params...))
+	last := args.PopLast().Elem()
+	if last == nil {
+		return args
+	}
+	value := reflect.ValueOf(last)
+	if err := args.AddAll(value); err != nil {
+		args.Add(value)
+	}
+	return args
+}
+
+func (c *callList) getVarCall(shaped, flat []interface{}, equal func(expected, actual interface{}) bool) (*call, []interface{}) {
+	for _, item := range c.list {
+		params := flat
+		if variadicStub(item.params) {
+			params = shaped
+		}
+		args := common.Args(params)
+		if item.params.MatchesWith(&args, equal) {
+			return item.call, params
+		}
+	}
+	return nil, shaped
+}
+
+func variadicStub(params common.Args) bool {
+	if len(params) == 0 {
+		return false
+	}
+	switch params[len(params)-1].(type) {
+	case tailMatcher, variadicMatcher:
+		return true
+	}
+	return false
+}
+
+type tailMatcher common.MatcherFunc
+
+func (t tailMatcher) Match(actual interface{}) bool {
+	return t(actual)
+}
+
+type variadicMatcher common.MatcherFunc
+
+func (v variadicMatcher) Match(actual interface{}) bool {
+	return v(actual)
+}
+
+func WithVariadic(matchers ...interface{}) common.Matcher {
+	elements := common.Args{}
+	tails := []tailMatcher{}
+	for _, matcher := range matchers {
+		if tail, ok := matcher.(tailMatcher); ok {
+			tails = append(tails, tail)
+		} else {
+			elements = append(elements, matcher)
+		}
+	}
+	return variadicMatcher(func(actual interface{}) bool {
+		tail, ok := toTail(actual)
+		if !ok {
+			return false
+		}
+		for _, matcher := range tails {
+			if !matcher(actual) {
+				return false
+			}
+		}
+		if len(elements) == 0 && len(tails) > 0 {
+			return true
+		}
+		return tail.Len() == elements.Len() && elements.Matches(tail)
+	})
+}
+
+func VarLen(size int) common.Matcher {
+	return tailMatcher(func(actual interface{}) bool {
+		tail, ok := toTail(actual)
+		return ok && tail.Len() == size
+	})
+}
+
+func VarContains(values ...interface{}) common.Matcher {
+	return tailMatcher(func(actual interface{}) bool {
+		tail, ok := toTail(actual)
+		if !ok {
+			return false
+		}
+		for _, value := range values {
+			expected := common.Args{value}
+			found := tail.Some(func(item *common.Arg, index int) bool {
+				return expected.Matches(&common.Args{item.Elem()})
+			})
+			if found.Len() == 0 {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func VarNil() common.Matcher {
+	return tailMatcher(func(actual interface{}) bool {
+		value := reflect.ValueOf(actual)
+		return actual == nil || (value.Kind() == reflect.Slice && value.IsNil())
+	})
+}
+
+func toTail(actual interface{}) (*common.Args, bool) {
+	tail := &common.Args{}
+	if actual == nil {
+		return tail, true
+	}
+	value := reflect.ValueOf(actual)
+	if value.Kind() != reflect.Slice {
+		return nil, false
+	}
+	tail.AddAll(value)
+	return tail, true
+}
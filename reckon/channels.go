@@ -0,0 +1,143 @@
+package reckon
+
+import (
+	"../common"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+var channelExpectations = map[string]Expectation{
+	"is closed": Expectation{
+		Message:    "Is not closed",
+		NotMessage: "Is closed",
+		Condition: func(args *common.Args) bool {
+			closed, value, received := probeClosed(channelOf(args.Get(0).Elem()))
+			if received {
+				panic(fmt.Sprintf("Is not closed: received %v", value))
+			}
+			return closed
+		},
+	},
+	"has buffered": Expectation{
+		Message:    "Does not have %v buffered values",
+		NotMessage: "Has %v buffered values",
+		Params:     []int{1},
+		Condition: func(args *common.Args) bool {
+			return channelOf(args.Get(0).Elem()).Len() == args.Get(1).Int()
+		},
+		Format: func(state bool, args *common.Args) string {
+			if !state {
+				return ""
+			}
+			return fmt.Sprintf("Has %v buffered values, expected %v", channelOf(args.Get(0).Elem()).Len(), args.Get(1).Int())
+		},
+	},
+}
+
+func channelOf(actual interface{}) reflect.Value {
+	ch := reflect.ValueOf(actual)
+	if ch.Kind() != reflect.Chan || ch.Type().ChanDir()&reflect.RecvDir == 0 {
+		panic("Value is not a receivable channel")
+	}
+	return ch
+}
+
+func probeClosed(ch reflect.Value) (bool, interface{}, bool) {
+	if ch.Len() > 0 {
+		return false, nil, false
+	}
+	chosen, value, ok := reflect.Select([]reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: ch},
+		{Dir: reflect.SelectDefault},
+	})
+	if chosen != 0 {
+		return false, nil, false
+	}
+	if !ok {
+		return true, nil, false
+	}
+	return false, value.Interface(), true
+}
+
+func (p *panicCheck) Receive(within time.Duration) *reckoning {
+	p.r.helper()
+	ch, ok := p.channel()
+	if !ok {
+		return newReckoning(p.r, nil)
+	}
+	value, received, message := p.receive(ch, within)
+	if p.state && !received {
+		p.r.fail(message)
+	} else if !p.state && received {
+		p.r.fail(fmt.Sprintf("Received value: %v", value))
+	}
+	return newReckoning(p.r, value)
+}
+
+func (p *panicCheck) ReceiveValue(expected interface{}, within time.Duration) *reckoning {
+	p.r.helper()
+	ch, ok := p.channel()
+	if !ok {
+		return newReckoning(p.r, nil)
+	}
+	value, received, message := p.receive(ch, within)
+	if received {
+		p.r.check("equals", p.state, value, expected)
+	} else if p.state {
+		p.r.fail(message)
+	}
+	return newReckoning(p.r, value)
+}
+
+func (p *panicCheck) channel() (reflect.Value, bool) {
+	p.r.helper()
+	ch := reflect.ValueOf(p.actual)
+	if ch.Kind() != reflect.Chan || ch.Type().ChanDir()&reflect.RecvDir == 0 {
+		p.r.fail("Value is not a receivable channel")
+		return ch, false
+	}
+	return ch, true
+}
+
+func (p *panicCheck) receive(ch reflect.Value, within time.Duration) (interface{}, bool, string) {
+	timeout := p.r.clockOrReal().After(within)
+	chosen, received, ok := reflect.Select([]reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: ch},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(timeout)},
+	})
+	if chosen == 1 {
+		return nil, false, fmt.Sprintf("Did not receive within %v", within)
+	}
+	if !ok {
+		return nil, false, "Channel is closed"
+	}
+	return received.Interface(), true, ""
+}
+
+func (o *objCompare) Closed() *reckoning {
+	o.r.helper()
+	if _, ok := o.actual.(*poller); ok {
+		o.r.check("is closed", o.state, o.actual)
+		return newReckoning(o.r, nil)
+	}
+	ch := reflect.ValueOf(o.actual)
+	if ch.Kind() != reflect.Chan || ch.Type().ChanDir()&reflect.RecvDir == 0 {
+		o.r.fail("Value is not a receivable channel")
+		return newReckoning(o.r, nil)
+	}
+	closed, value, received := probeClosed(ch)
+	if o.state && received {
+		o.r.fail(fmt.Sprintf("Is not closed: received %v", value))
+	} else if o.state && !closed {
+		o.r.fail("Is not closed")
+	} else if !o.state && closed {
+		o.r.fail("Is closed")
+	}
+	return newReckoning(o.r, value)
+}
+
+func (o *owner) Buffered(count int) {
+	o.r.helper()
+	o.r.check("has buffered", o.state, o.actual, count)
+}
@@ -0,0 +1,322 @@
+package reckon
+
+import (
+	"../common"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const maxListed = 10
+
+var collectionExpectations = map[string]Expectation{
+	"contains element": Expectation{
+		Message:    "Does not contain element: %v",
+		NotMessage: "Does contain element: %v",
+		Params:     []int{1},
+		Condition: func(args *common.Args) bool {
+			return len(matchingElements(args.Get(0).Elem(), args.Get(1).Elem())) > 0
+		},
+		Format: func(state bool, args *common.Args) string {
+			if state {
+				return ""
+			}
+			found := matchingElements(args.Get(0).Elem(), args.Get(1).Elem())
+			return fmt.Sprintf("Does contain element: %v at %v", args.Get(1).Elem(), listLabels(found))
+		},
+	},
+	"has all elements": Expectation{
+		Message:    "Not all elements match",
+		NotMessage: "All elements match",
+		Condition: func(args *common.Args) bool {
+			return len(failingElements(args.Get(0).Elem(), args.Get(1).Elem())) == 0
+		},
+		Format: func(state bool, args *common.Args) string {
+			if !state {
+				return ""
+			}
+			return "Elements do not match: " + listElements(failingElements(args.Get(0).Elem(), args.Get(1).Elem()))
+		},
+	},
+	"has any element": Expectation{
+		Message:    "No element matches",
+		NotMessage: "Elements match",
+		Condition: func(args *common.Args) bool {
+			return len(matchingElements(args.Get(0).Elem(), args.Get(1).Elem())) > 0
+		},
+		Format: func(state bool, args *common.Args) string {
+			if state {
+				return ""
+			}
+			return "Elements match: " + listElements(matchingElements(args.Get(0).Elem(), args.Get(1).Elem()))
+		},
+	},
+	"has exactly matching": Expectation{
+		Message:    "Does not have exactly %v matching elements",
+		NotMessage: "Has exactly %v matching elements",
+		Params:     []int{1},
+		Condition: func(args *common.Args) bool {
+			return len(matchingElements(args.Get(0).Elem(), args.Get(2).Elem())) == args.Get(1).Int()
+		},
+		Format: func(state bool, args *common.Args) string {
+			if !state {
+				return ""
+			}
+			found := matchingElements(args.Get(0).Elem(), args.Get(2).Elem())
+			if len(found) == 0 {
+				return fmt.Sprintf("Has 0 matching elements, expected %v", args.Get(1).Int())
+			}
+			return fmt.Sprintf("Has %v matching elements, expected %v: %v", len(found), args.Get(1).Int(), listElements(found))
+		},
+	},
+	"has unique": Expectation{
+		Message:    "Has duplicate elements",
+		NotMessage: "All elements are unique",
+		Condition: func(args *common.Args) bool {
+			return len(duplicateElements(args.Get(0).Elem())) == 0
+		},
+		Format: func(state bool, args *common.Args) string {
+			if !state {
+				return ""
+			}
+			return "Has duplicate elements: " + listElements(duplicateElements(args.Get(0).Elem()))
+		},
+	},
+	"has subset": Expectation{
+		Message:    "Does not have subset",
+		NotMessage: "Does have subset",
+		Condition: func(args *common.Args) bool {
+			return len(missingElements(args.Get(0).Elem(), args.Get(1).Elem())) == 0
+		},
+		Format: func(state bool, args *common.Args) string {
+			if !state {
+				return ""
+			}
+			return "Does not have subset, missing: " + listElements(missingElements(args.Get(0).Elem(), args.Get(1).Elem()))
+		},
+	},
+	"is equivalent to": Expectation{
+		Message:    "Items not equivalent",
+		NotMessage: "Items equivalent",
+		Condition: func(args *common.Args) bool {
+			missing, extra := compareElements(args.Get(0).Elem(), args.Get(1).Elem())
+			return len(missing) == 0 && len(extra) == 0
+		},
+		Format: func(state bool, args *common.Args) string {
+			if !state {
+				return ""
+			}
+			missing, extra := compareElements(args.Get(0).Elem(), args.Get(1).Elem())
+			lines := []string{"Items not equivalent:"}
+			if len(missing) > 0 {
+				lines = append(lines, "\tMissing: "+listElements(missing))
+			}
+			if len(extra) > 0 {
+				lines = append(lines, "\tExtra: "+listElements(extra))
+			}
+			return strings.Join(lines, "\n")
+		},
+	},
+	"is sorted": Expectation{
+		Message:    "Is not sorted",
+		NotMessage: "Is sorted",
+		Condition: func(args *common.Args) bool {
+			return unsortedAt(args.Get(0).Elem(), args.Get(1).Elem()) < 0
+		},
+		Format: func(state bool, args *common.Args) string {
+			if !state {
+				return ""
+			}
+			list := elementsOf(args.Get(0).Elem())
+			index := unsortedAt(args.Get(0).Elem(), args.Get(1).Elem())
+			return fmt.Sprintf("Is not sorted: %v before %v", list[index-1], list[index])
+		},
+	},
+}
+
+type element struct {
+	label string
+	value reflect.Value
+}
+
+func (e element) String() string {
+	return e.label + ": " + formatValue(e.value)
+}
+
+func elementsOf(collection interface{}) []element {
+	value := reflect.ValueOf(collection)
+	list := []element{}
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for index := 0; index < value.Len(); index++ {
+			list = append(list, element{fmt.Sprintf("[%v]", index), value.Index(index)})
+		}
+	case reflect.Map:
+		keys := value.MapKeys()
+		sort.Slice(keys, func(a, b int) bool {
+			return formatFull(keys[a]) < formatFull(keys[b])
+		})
+		for _, key := range keys {
+			list = append(list, element{fmt.Sprintf("[%v]", formatFull(key)), value.MapIndex(key)})
+		}
+	default:
+		panic("Value is not a collection")
+	}
+	return list
+}
+
+func elementPredicate(predicate interface{}) func(value reflect.Value) bool {
+	if matcher, ok := predicate.(common.Matcher); ok {
+		return func(value reflect.Value) bool {
+			return matcher.Match(value.Interface())
+		}
+	}
+	fn := reflect.ValueOf(predicate)
+	if fn.Kind() != reflect.Func {
+		return func(value reflect.Value) bool {
+			return reflect.DeepEqual(value.Interface(), predicate)
+		}
+	}
+	signature := fn.Type()
+	if signature.NumIn() != 1 || signature.NumOut() != 1 || signature.Out(0).Kind() != reflect.Bool {
+		panic("Predicate must be a func(T) bool")
+	}
+	return func(value reflect.Value) bool {
+		if !value.Type().AssignableTo(signature.In(0)) {
+			if value.Kind() != reflect.Interface || value.IsNil() || !value.Elem().Type().AssignableTo(signature.In(0)) {
+				panic(fmt.Sprintf("Predicate expects %v, got %v", signature.In(0), value.Type()))
+			}
+			value = value.Elem()
+		}
+		return fn.Call([]reflect.Value{value})[0].Bool()
+	}
+}
+
+func matchingElements(collection, predicate interface{}) []element {
+	match := elementPredicate(predicate)
+	found := []element{}
+	for _, item := range elementsOf(collection) {
+		if match(item.value) {
+			found = append(found, item)
+		}
+	}
+	return found
+}
+
+func failingElements(collection, predicate interface{}) []element {
+	match := elementPredicate(predicate)
+	found := []element{}
+	for _, item := range elementsOf(collection) {
+		if !match(item.value) {
+			found = append(found, item)
+		}
+	}
+	return found
+}
+
+func duplicateElements(collection interface{}) []element {
+	list := elementsOf(collection)
+	found := []element{}
+	for index, item := range list {
+		for _, other := range list[:index] {
+			if reflect.DeepEqual(item.value.Interface(), other.value.Interface()) {
+				found = append(found, item)
+				break
+			}
+		}
+	}
+	return found
+}
+
+func compareElements(actual, expected interface{}) ([]element, []element) {
+	extra := elementsOf(actual)
+	missing := []element{}
+	for _, item := range elementsOf(expected) {
+		index := indexOfElement(extra, item.value)
+		if index < 0 {
+			missing = append(missing, item)
+		} else {
+			extra = append(extra[:index], extra[index+1:]...)
+		}
+	}
+	return missing, extra
+}
+
+func missingElements(actual, subset interface{}) []element {
+	list := elementsOf(actual)
+	missing := []element{}
+	for _, item := range elementsOf(subset) {
+		if indexOfElement(list, item.value) < 0 {
+			missing = append(missing, item)
+		}
+	}
+	return missing
+}
+
+func indexOfElement(list []element, value reflect.Value) int {
+	for index, item := range list {
+		if reflect.DeepEqual(item.value.Interface(), value.Interface()) {
+			return index
+		}
+	}
+	return -1
+}
+
+func unsortedAt(collection, less interface{}) int {
+	list := elementsOf(collection)
+	compare := lessFunc(less)
+	for index := 1; index < len(list); index++ {
+		if compare(list[index].value, list[index-1].value) {
+			return index
+		}
+	}
+	return -1
+}
+
+func lessFunc(less interface{}) func(a, b reflect.Value) bool {
+	if less == nil {
+		return func(a, b reflect.Value) bool {
+			a, b = reflect.Indirect(a), reflect.Indirect(b)
+			if a.Kind() == reflect.Interface {
+				a = a.Elem()
+			}
+			if b.Kind() == reflect.Interface {
+				b = b.Elem()
+			}
+			if a.Kind() == reflect.String && b.Kind() == reflect.String {
+				return a.String() < b.String()
+			}
+			cmp, ok := compareNumbers(a.Interface(), b.Interface())
+			return ok && cmp < 0
+		}
+	}
+	fn := reflect.ValueOf(less)
+	signature := fn.Type()
+	if fn.Kind() != reflect.Func || signature.NumIn() != 2 || signature.NumOut() != 1 || signature.Out(0).Kind() != reflect.Bool {
+		panic("Ordering must be a func(a, b T) bool")
+	}
+	return func(a, b reflect.Value) bool {
+		return fn.Call([]reflect.Value{a, b})[0].Bool()
+	}
+}
+
+func listElements(list []element) string {
+	out := []string{}
+	for index, item := range list {
+		if index == maxListed {
+			out = append(out, fmt.Sprintf("... and %v more", len(list)-maxListed))
+			break
+		}
+		out = append(out, item.String())
+	}
+	return strings.Join(out, ", ")
+}
+
+func listLabels(list []element) string {
+	out := []string{}
+	for _, item := range list {
+		out = append(out, item.label)
+	}
+	return strings.Join(out, ", ")
+}
@@ -0,0 +1,289 @@
+package reckon
+
+import (
+	"../common"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	maxValueLength = 80
+	maxDiffLines   = 20
+	maxDiffDepth   = 32
+	maxDiffCells   = 1 << 20
+	diffContext    = 3
+)
+
+var colorOutput = false
+
+func UseColor(enabled bool) {
+	colorOutput = enabled
+}
+
+func formatEquals(state bool, args *common.Args) string {
+	if !state {
+		return ""
+	}
+	actual := args.Get(0).Elem()
+	expected := args.Get(1).Elem()
+	lines := []string{}
+	if a, ok := actual.(string); ok {
+		if e, ok := expected.(string); ok && (strings.Contains(a, "\n") || strings.Contains(e, "\n")) {
+			lines = lineDiff(strings.Split(a, "\n"), strings.Split(e, "\n"))
+		}
+	} else if isStructured(reflect.ValueOf(expected)) || isStructured(reflect.ValueOf(actual)) {
+		diffValues("", reflect.ValueOf(actual), reflect.ValueOf(expected), &lines, 0)
+		if len(lines) > maxDiffLines {
+			lines = append(lines[:maxDiffLines], fmt.Sprintf("... and %v more differences", len(lines)-maxDiffLines))
+		}
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return "Items not equal:\n\t" + strings.Join(lines, "\n\t")
+}
+
+func isStructured(value reflect.Value) bool {
+	for value.IsValid() && (value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface) {
+		if value.IsNil() {
+			return false
+		}
+		value = value.Elem()
+	}
+	switch value.Kind() {
+	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	}
+	return false
+}
+
+func diffValues(path string, actual, expected reflect.Value, lines *[]string, depth int) {
+	if depth > maxDiffDepth {
+		return
+	}
+	if !actual.IsValid() || !expected.IsValid() {
+		if actual.IsValid() != expected.IsValid() {
+			*lines = append(*lines, mismatch(path, actual, expected))
+		}
+		return
+	}
+	if actual.Type() != expected.Type() {
+		*lines = append(*lines, fmt.Sprintf("%v: %v (%v) != %v (%v)", root(path),
+			colorize(formatValue(actual), red), actual.Type(), colorize(formatValue(expected), green), expected.Type()))
+		return
+	}
+	switch actual.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if actual.IsNil() || expected.IsNil() {
+			if actual.IsNil() != expected.IsNil() {
+				*lines = append(*lines, mismatch(path, actual, expected))
+			}
+			return
+		}
+		diffValues(path, actual.Elem(), expected.Elem(), lines, depth+1)
+	case reflect.Struct:
+		for index := 0; index < actual.NumField(); index++ {
+			name := actual.Type().Field(index).Name
+			diffValues(path+"."+name, actual.Field(index), expected.Field(index), lines, depth+1)
+		}
+	case reflect.Slice, reflect.Array:
+		if actual.Len() != expected.Len() {
+			*lines = append(*lines, fmt.Sprintf("%v: length %v != %v", root(path),
+				colorize(fmt.Sprint(actual.Len()), red), colorize(fmt.Sprint(expected.Len()), green)))
+		}
+		count := actual.Len()
+		if expected.Len() < count {
+			count = expected.Len()
+		}
+		for index := 0; index < count; index++ {
+			diffValues(fmt.Sprintf("%v[%v]", path, index), actual.Index(index), expected.Index(index), lines, depth+1)
+		}
+	case reflect.Map:
+		for _, key := range sortedKeys(actual, expected) {
+			keyPath := fmt.Sprintf("%v[%v]", path, formatValue(key))
+			a := actual.MapIndex(key)
+			e := expected.MapIndex(key)
+			switch {
+			case !a.IsValid():
+				*lines = append(*lines, fmt.Sprintf("%v: missing != %v", keyPath, colorize(formatValue(e), green)))
+			case !e.IsValid():
+				*lines = append(*lines, fmt.Sprintf("%v: %v != missing", keyPath, colorize(formatValue(a), red)))
+			default:
+				diffValues(keyPath, a, e, lines, depth+1)
+			}
+		}
+	default:
+		if formatFull(actual) != formatFull(expected) {
+			*lines = append(*lines, mismatch(path, actual, expected))
+		}
+	}
+}
+
+func sortedKeys(actual, expected reflect.Value) []reflect.Value {
+	seen := map[string]bool{}
+	keys := []reflect.Value{}
+	for _, source := range []reflect.Value{actual, expected} {
+		for _, key := range source.MapKeys() {
+			label := formatFull(key)
+			if !seen[label] {
+				seen[label] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Slice(keys, func(a, b int) bool {
+		return formatFull(keys[a]) < formatFull(keys[b])
+	})
+	return keys
+}
+
+func mismatch(path string, actual, expected reflect.Value) string {
+	return fmt.Sprintf("%v: %v != %v", root(path), colorize(formatValue(actual), red), colorize(formatValue(expected), green))
+}
+
+func root(path string) string {
+	if len(path) == 0 {
+		return "."
+	}
+	return path
+}
+
+func formatFull(value reflect.Value) string {
+	if !value.IsValid() {
+		return "<nil>"
+	}
+	if value.Kind() == reflect.String {
+		return fmt.Sprintf("%q", value.String())
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+func formatValue(value reflect.Value) string {
+	out := formatFull(value)
+	if len(out) > maxValueLength {
+		out = out[:maxValueLength-3] + "..."
+	}
+	return out
+}
+
+func lineDiff(actual, expected []string) []string {
+	if len(actual)*len(expected) > maxDiffCells {
+		return firstLineDiff(actual, expected)
+	}
+	table := make([][]int, len(expected)+1)
+	for index := range table {
+		table[index] = make([]int, len(actual)+1)
+	}
+	for e := len(expected) - 1; e >= 0; e-- {
+		for a := len(actual) - 1; a >= 0; a-- {
+			if expected[e] == actual[a] {
+				table[e][a] = table[e+1][a+1] + 1
+			} else if table[e+1][a] >= table[e][a+1] {
+				table[e][a] = table[e+1][a]
+			} else {
+				table[e][a] = table[e][a+1]
+			}
+		}
+	}
+	ops := []diffLine{}
+	e, a := 0, 0
+	for e < len(expected) || a < len(actual) {
+		switch {
+		case e < len(expected) && a < len(actual) && expected[e] == actual[a]:
+			ops = append(ops, diffLine{' ', expected[e]})
+			e++
+			a++
+		case a >= len(actual) || (e < len(expected) && table[e+1][a] >= table[e][a+1]):
+			ops = append(ops, diffLine{'-', expected[e]})
+			e++
+		default:
+			ops = append(ops, diffLine{'+', actual[a]})
+			a++
+		}
+	}
+	return renderDiff(ops)
+}
+
+type diffLine struct {
+	kind byte
+	text string
+}
+
+func renderDiff(ops []diffLine) []string {
+	near := make([]bool, len(ops))
+	changes := 0
+	for index, op := range ops {
+		if op.kind == ' ' {
+			continue
+		}
+		changes++
+		for offset := -diffContext; offset <= diffContext; offset++ {
+			if index+offset >= 0 && index+offset < len(ops) {
+				near[index+offset] = true
+			}
+		}
+	}
+	lines := []string{"--- expected", "+++ actual"}
+	shown := 0
+	skipped := false
+	for index, op := range ops {
+		if !near[index] {
+			skipped = true
+			continue
+		}
+		if op.kind != ' ' && shown == maxDiffLines {
+			return append(lines, fmt.Sprintf("... and %v more differences", changes-shown))
+		}
+		if skipped {
+			lines = append(lines, "...")
+			skipped = false
+		}
+		switch op.kind {
+		case ' ':
+			lines = append(lines, " "+op.text)
+		case '-':
+			lines = append(lines, colorize("-"+op.text, green))
+			shown++
+		default:
+			lines = append(lines, colorize("+"+op.text, red))
+			shown++
+		}
+	}
+	if skipped {
+		lines = append(lines, "...")
+	}
+	return lines
+}
+
+func firstLineDiff(actual, expected []string) []string {
+	index := 0
+	for index < len(actual) && index < len(expected) && actual[index] == expected[index] {
+		index++
+	}
+	lines := []string{
+		"--- expected",
+		"+++ actual",
+		fmt.Sprintf("first difference at line %v (%v expected, %v actual lines)", index+1, len(expected), len(actual)),
+	}
+	if index < len(expected) {
+		lines = append(lines, colorize("-"+formatValue(reflect.ValueOf(expected[index])), green))
+	}
+	if index < len(actual) {
+		lines = append(lines, colorize("+"+formatValue(reflect.ValueOf(actual[index])), red))
+	}
+	return lines
+}
+
+const (
+	red   = "31"
+	green = "32"
+)
+
+func colorize(text, color string) string {
+	if !colorOutput {
+		return text
+	}
+	return "\x1b[" + color + "m" + text + "\x1b[0m"
+}
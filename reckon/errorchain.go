@@ -0,0 +1,129 @@
+package reckon
+
+import (
+	"../common"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+var errorExpectations = map[string]Expectation{
+	"error matching": Expectation{
+		Message:    "Error does not match %v",
+		NotMessage: "Error matches %v",
+		Params:     []int{1},
+		Condition: func(args *common.Args) bool {
+			target, _ := args.Get(1).Elem().(error)
+			return errors.Is(toError(args.Get(0).Elem()), target)
+		},
+		Format: func(state bool, args *common.Args) string {
+			message := "Error matches %v"
+			if state {
+				message = "Error does not match %v"
+			}
+			return fmt.Sprintf(message, args.Get(1).Elem()) + formatChain(toError(args.Get(0).Elem()))
+		},
+	},
+	"error of type": Expectation{
+		Message:    "Error is not of type",
+		NotMessage: "Error is of type",
+		Condition: func(args *common.Args) bool {
+			target := args.Get(1).ValueOf()
+			if target.Kind() != reflect.Ptr || target.IsNil() {
+				panic("Target must be a non-nil pointer")
+			}
+			err := toError(args.Get(0).Elem())
+			return err != nil && errors.As(err, args.Get(1).Elem())
+		},
+		Format: func(state bool, args *common.Args) string {
+			message := "Error is of type %v"
+			if state {
+				message = "Error is not of type %v"
+			}
+			return fmt.Sprintf(message, args.Get(1).TypeOf().Elem()) + formatChain(toError(args.Get(0).Elem()))
+		},
+	},
+	"has error message": Expectation{
+		Message:    "No error in chain has message %v",
+		NotMessage: "Error in chain has message %v",
+		Params:     []int{1},
+		Condition: func(args *common.Args) bool {
+			match := messageMatcher(args.Get(1).Elem())
+			for _, err := range errorChain(toError(args.Get(0).Elem())) {
+				if match(err.Error()) {
+					return true
+				}
+			}
+			return false
+		},
+		Format: func(state bool, args *common.Args) string {
+			message := "Error in chain has message %v"
+			if state {
+				message = "No error in chain has message %v"
+			}
+			return fmt.Sprintf(message, args.Get(1).Elem()) + formatChain(toError(args.Get(0).Elem()))
+		},
+	},
+	"nil error": Expectation{
+		Message:    "Error is not nil",
+		NotMessage: "Error is nil",
+		Condition: func(args *common.Args) bool {
+			return toError(args.Get(0).Elem()) == nil
+		},
+		Format: func(state bool, args *common.Args) string {
+			if !state {
+				return ""
+			}
+			return "Error is not nil" + formatChain(toError(args.Get(0).Elem()))
+		},
+	},
+}
+
+func toError(actual interface{}) error {
+	if actual == nil {
+		return nil
+	}
+	err, ok := actual.(error)
+	if !ok {
+		panic("Value is not an error")
+	}
+	return err
+}
+
+func errorChain(err error) []error {
+	if err == nil {
+		return []error{}
+	}
+	chain := []error{err}
+	switch wrapped := err.(type) {
+	case interface{ Unwrap() error }:
+		chain = append(chain, errorChain(wrapped.Unwrap())...)
+	case interface{ Unwrap() []error }:
+		for _, inner := range wrapped.Unwrap() {
+			chain = append(chain, errorChain(inner)...)
+		}
+	}
+	return chain
+}
+
+func formatChain(err error) string {
+	lines := []string{}
+	for _, item := range errorChain(err) {
+		lines = append(lines, fmt.Sprintf("\n\t%T: %v", item, item))
+	}
+	return strings.Join(lines, "")
+}
+
+func messageMatcher(pattern interface{}) func(message string) bool {
+	switch value := pattern.(type) {
+	case *regexp.Regexp:
+		return value.MatchString
+	case string:
+		return func(message string) bool {
+			return strings.Contains(message, value)
+		}
+	}
+	panic("Message must be a string or *regexp.Regexp")
+}
@@ -7,18 +7,20 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 )
 
-var expectations = expectationSet(map[string]expectation{
-	"equals": expectation{
+var expectations = expectationSet(map[string]Expectation{
+	"equals": Expectation{
 		Message:    "Items not equal:\n\tActual: %v\n\tExpected: %v",
 		NotMessage: "Items equal:\n\tActual: %v\n\tExpected: %v",
 		Params:     []int{0, 1},
 		Condition: func(args *common.Args) bool {
 			return reflect.DeepEqual(args.Get(0), args.Get(1))
 		},
+		Format: formatEquals,
 	},
-	"exists": expectation{
+	"exists": Expectation{
 		Message:    "Does not exist",
 		NotMessage: "Does exist",
 		Condition: func(args *common.Args) bool {
@@ -26,7 +28,7 @@ var expectations = expectationSet(map[string]expectation{
 			return !actual.IsNil() && actual.IsValid()
 		},
 	},
-	"panics": expectation{
+	"panics": Expectation{
 		Message:    "Has not panicked",
 		NotMessage: "Has panicked",
 		Condition: func(args *common.Args) bool {
@@ -39,7 +41,7 @@ var expectations = expectationSet(map[string]expectation{
 			return true
 		},
 	},
-	"panics with message": expectation{
+	"panics with message": Expectation{
 		Message:    "Has panicked with incorrect message",
 		NotMessage: "Has panicked with correct message",
 		Condition: func(args *common.Args) bool {
@@ -52,7 +54,7 @@ var expectations = expectationSet(map[string]expectation{
 			return reflect.DeepEqual(err, args.Get(1).Elem())
 		},
 	},
-	"matches": expectation{
+	"matches": Expectation{
 		Message:    "Does not match string",
 		NotMessage: "Does match string",
 		Condition: func(args *common.Args) bool {
@@ -62,7 +64,7 @@ var expectations = expectationSet(map[string]expectation{
 			return exp.MatchString(actual)
 		},
 	},
-	"contains": expectation{
+	"contains": Expectation{
 		Message:    "Does not contain string",
 		NotMessage: "Does contain string",
 		Condition: func(args *common.Args) bool {
@@ -71,21 +73,21 @@ var expectations = expectationSet(map[string]expectation{
 			return strings.Contains(actual, needle)
 		},
 	},
-	"is a": expectation{
+	"is a": Expectation{
 		Message:    "Is not kind of",
 		NotMessage: "Is kind of",
 		Condition: func(args *common.Args) bool {
 			return args.Get(0).ValueOf().Kind() == args.Get(1).Elem()
 		},
 	},
-	"instance of": expectation{
+	"instance of": Expectation{
 		Message:    "Is not an instance of",
 		NotMessage: "Is an instance of",
 		Condition: func(args *common.Args) bool {
 			return reflect.DeepEqual(args.Get(0).TypeOf(), args.Get(1).Elem())
 		},
 	},
-	"is zero": expectation{
+	"is zero": Expectation{
 		Message:    "Is not the zero value for type",
 		NotMessage: "Is the zero value for type",
 		Condition: func(args *common.Args) bool {
@@ -95,35 +97,31 @@ var expectations = expectationSet(map[string]expectation{
 			return reflect.DeepEqual(myValue, zeroValue.Interface())
 		},
 	},
-	"greater than": expectation{
+	"greater than": Expectation{
 		Message:    "Is not greater than",
 		NotMessage: "Is greater than",
 		Condition: func(args *common.Args) bool {
-			myValue := args.Get(0).Float64()
-			bound := args.Get(1).Float64()
-			return myValue > bound
+			cmp, ok := compareNumbers(args.Get(0).Elem(), args.Get(1).Elem())
+			return ok && cmp > 0
 		},
 	},
-	"less than": expectation{
+	"less than": Expectation{
 		Message:    "Is not less than",
 		NotMessage: "Is less than",
 		Condition: func(args *common.Args) bool {
-			myValue := args.Get(0).Float64()
-			bound := args.Get(1).Float64()
-			return myValue < bound
+			cmp, ok := compareNumbers(args.Get(0).Elem(), args.Get(1).Elem())
+			return ok && cmp < 0
 		},
 	},
-	"within": expectation{
+	"within": Expectation{
 		Message:    "Is not within",
 		NotMessage: "Is within",
 		Condition: func(args *common.Args) bool {
-			myValue := args.Get(0).Float64()
-			low := args.Get(1).Float64()
-			high := args.Get(2).Float64()
-			return myValue > low && myValue < high
+			bounds, _ := args.Get(3).Elem().(Bounds)
+			return withinBounds(args.Get(0).Elem(), args.Get(1).Elem(), args.Get(2).Elem(), bounds)
 		},
 	},
-	"has keys": expectation{
+	"has keys": Expectation{
 		Message:    "Does not have keys",
 		NotMessage: "Does have keys",
 		Condition: func(args *common.Args) bool {
@@ -149,7 +147,7 @@ var expectations = expectationSet(map[string]expectation{
 			return !state
 		},
 	},
-	"has properties": expectation{
+	"has properties": Expectation{
 		Message:    "Does not have properties",
 		NotMessage: "Does have properties",
 		Condition: func(args *common.Args) bool {
@@ -176,7 +174,7 @@ var expectations = expectationSet(map[string]expectation{
 			return !state
 		},
 	},
-	"has property": expectation{
+	"has property": Expectation{
 		Message:    "Does not have property",
 		NotMessage: "Does have property",
 		Condition: func(args *common.Args) bool {
@@ -194,7 +192,7 @@ var expectations = expectationSet(map[string]expectation{
 			return matches.Len() > 0
 		},
 	},
-	"has deep property": expectation{
+	"has deep property": Expectation{
 		Message:    "Does not have deep property",
 		NotMessage: "Does have deep property",
 		Condition: func(args *common.Args) bool {
@@ -272,30 +270,84 @@ func safeCall(fn reflect.Value, err interface{}) {
 	fn.Call([]reflect.Value{})
 }
 
-type expectationSet map[string]expectation
+type expectationSet map[string]Expectation
+
+var expectationsLock sync.RWMutex
 
 func (e expectationSet) check(name string, state bool, params ...interface{}) {
-	exp, ok := e[name]
+	exp, ok := e.lookup(name)
 	if ok {
 		exp.check(state, params)
 	}
 }
 
-type expectation struct {
+func (e expectationSet) lookup(name string) (Expectation, bool) {
+	expectationsLock.RLock()
+	defer expectationsLock.RUnlock()
+	exp, ok := e[name]
+	return exp, ok
+}
+
+func Register(name string, exp Expectation) error {
+	if exp.Condition == nil {
+		return fmt.Errorf("expectation has no condition: %v", name)
+	}
+	expectationsLock.Lock()
+	defer expectationsLock.Unlock()
+	if _, ok := expectations[name]; ok {
+		return fmt.Errorf("expectation already registered: %v", name)
+	}
+	expectations[name] = exp
+	return nil
+}
+
+func init() {
+	for _, set := range []map[string]Expectation{
+		collectionExpectations,
+		errorExpectations,
+		numberExpectations,
+		channelExpectations,
+	} {
+		for name, exp := range set {
+			if err := Register(name, exp); err != nil {
+				panic(err.Error())
+			}
+		}
+	}
+}
+
+type Expectation struct {
 	Message    string
 	NotMessage string
 	Params     []int
 	Condition  func(args *common.Args) bool
+	Format     func(state bool, args *common.Args) string
 }
 
-func (e *expectation) checkBase(state bool, message string, params []interface{}) {
+func (e *Expectation) checkBase(state bool, message string, params []interface{}) {
 	args := common.Args(params)
 	if state != e.Condition(&args) {
-		panic(e.buildMessage(message, params))
+		panic(e.buildMessage(state, message, params))
 	}
 }
 
-func (e *expectation) buildMessage(message string, params []interface{}) string {
+func (e *Expectation) evaluate(state bool, params []interface{}) (message string) {
+	defer func() {
+		if r := recover(); r != nil {
+			message = fmt.Sprintf("%v", r)
+		}
+	}()
+	e.check(state, params)
+	return ""
+}
+
+func (e *Expectation) buildMessage(state bool, message string, params []interface{}) string {
+	if e.Format != nil {
+		args := common.Args(params)
+		if out := e.Format(state, &args); len(out) > 0 {
+			return out
+		}
+	}
 	args := []interface{}{}
 	for _, index := range e.Params {
 		args = append(args, params[index])
@@ -303,7 +355,7 @@ func (e *expectation) buildMessage(message string, params []interface{}) string
 	return fmt.Sprintf(message, args...)
 }
 
-func (e *expectation) check(state bool, params []interface{}) {
+func (e *Expectation) check(state bool, params []interface{}) {
 	message := ""
 	if state {
 		message = e.Message
@@ -0,0 +1,189 @@
+package reckon
+
+import (
+	"../common"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+)
+
+type Bounds int
+
+const (
+	Exclusive Bounds = iota
+	Inclusive
+	InclusiveLow
+	InclusiveHigh
+)
+
+var numberExpectations = map[string]Expectation{
+	"at least": Expectation{
+		Message:    "Is not at least %v",
+		NotMessage: "Is at least %v",
+		Params:     []int{1},
+		Condition: func(args *common.Args) bool {
+			cmp, ok := compareNumbers(args.Get(0).Elem(), args.Get(1).Elem())
+			return ok && cmp >= 0
+		},
+	},
+	"at most": Expectation{
+		Message:    "Is not at most %v",
+		NotMessage: "Is at most %v",
+		Params:     []int{1},
+		Condition: func(args *common.Args) bool {
+			cmp, ok := compareNumbers(args.Get(0).Elem(), args.Get(1).Elem())
+			return ok && cmp <= 0
+		},
+	},
+	"close to": Expectation{
+		Message:    "Is not close to %v ± %v",
+		NotMessage: "Is close to %v ± %v",
+		Params:     []int{1, 2},
+		Condition: func(args *common.Args) bool {
+			difference := numberDifference(args.Get(0).Elem(), args.Get(1).Elem())
+			cmp, ok := compareNumbers(difference, args.Get(2).Elem())
+			return ok && cmp <= 0
+		},
+		Format: func(state bool, args *common.Args) string {
+			message := "Is close to %v ± %v: difference %v"
+			if state {
+				message = "Is not close to %v ± %v: difference %v"
+			}
+			difference := numberDifference(args.Get(0).Elem(), args.Get(1).Elem())
+			return fmt.Sprintf(message, args.Get(1).Elem(), args.Get(2).Elem(), difference.Text('g', 10))
+		},
+	},
+	"close to ulp": Expectation{
+		Message:    "Is not within %v ULPs of %v",
+		NotMessage: "Is within %v ULPs of %v",
+		Params:     []int{2, 1},
+		Condition: func(args *common.Args) bool {
+			distance, ok := ulpDistance(args.Get(0).Elem(), args.Get(1).Elem())
+			return ok && distance <= args.Get(2).UInt64()
+		},
+		Format: func(state bool, args *common.Args) string {
+			message := "Is within %v ULPs of %v: distance %v"
+			if state {
+				message = "Is not within %v ULPs of %v: distance %v"
+			}
+			distance, ok := ulpDistance(args.Get(0).Elem(), args.Get(1).Elem())
+			if !ok {
+				return fmt.Sprintf(message, args.Get(2).Elem(), args.Get(1).Elem(), "NaN")
+			}
+			return fmt.Sprintf(message, args.Get(2).Elem(), args.Get(1).Elem(), distance)
+		},
+	},
+}
+
+func toBig(value interface{}) (*big.Float, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Float).SetInt64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Float).SetUint64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		if math.IsNaN(v.Float()) {
+			return nil, false
+		}
+		return new(big.Float).SetFloat64(v.Float()), true
+	case reflect.Ptr:
+		if f, ok := value.(*big.Float); ok && f != nil {
+			return f, true
+		}
+		if i, ok := value.(*big.Int); ok && i != nil {
+			return new(big.Float).SetInt(i), true
+		}
+	}
+	panic(fmt.Sprintf("Value is not a number: %v", value))
+}
+
+func compareNumbers(actual, bound interface{}) (int, bool) {
+	a, ok := toBig(actual)
+	if !ok {
+		return 0, false
+	}
+	b, ok := toBig(bound)
+	if !ok {
+		return 0, false
+	}
+	return a.Cmp(b), true
+}
+
+func numberDifference(actual, expected interface{}) *big.Float {
+	a, ok := toBig(actual)
+	if !ok {
+		return big.NewFloat(math.Inf(1))
+	}
+	e, ok := toBig(expected)
+	if !ok {
+		return big.NewFloat(math.Inf(1))
+	}
+	if a.Cmp(e) == 0 {
+		return new(big.Float)
+	}
+	if a.IsInf() || e.IsInf() {
+		return big.NewFloat(math.Inf(1))
+	}
+	difference := new(big.Float).SetPrec(256).Sub(a, e)
+	return difference.Abs(difference)
+}
+
+func withinBounds(actual, low, high interface{}, bounds Bounds) bool {
+	lower, ok := compareNumbers(actual, low)
+	if !ok {
+		return false
+	}
+	upper, ok := compareNumbers(actual, high)
+	if !ok {
+		return false
+	}
+	lowOK := lower > 0 || (lower == 0 && (bounds == Inclusive || bounds == InclusiveLow))
+	highOK := upper < 0 || (upper == 0 && (bounds == Inclusive || bounds == InclusiveHigh))
+	return lowOK && highOK
+}
+
+func ulpDistance(actual, expected interface{}) (uint64, bool) {
+	a := reflect.ValueOf(actual)
+	e := reflect.ValueOf(expected)
+	if a.Kind() != reflect.Float32 && a.Kind() != reflect.Float64 {
+		panic(fmt.Sprintf("Value is not a float: %v", actual))
+	}
+	if e.Kind() != reflect.Float32 && e.Kind() != reflect.Float64 {
+		panic(fmt.Sprintf("Value is not a float: %v", expected))
+	}
+	if math.IsNaN(a.Float()) || math.IsNaN(e.Float()) {
+		return 0, false
+	}
+	if a.Kind() == reflect.Float32 {
+		x := orderedBits32(float32(a.Float()))
+		y := orderedBits32(float32(e.Float()))
+		if x > y {
+			return uint64(x - y), true
+		}
+		return uint64(y - x), true
+	}
+	x := orderedBits64(a.Float())
+	y := orderedBits64(e.Float())
+	if x > y {
+		return x - y, true
+	}
+	return y - x, true
+}
+
+func orderedBits64(f float64) uint64 {
+	bits := math.Float64bits(f)
+	if bits&(1<<63) != 0 {
+		return ^bits
+	}
+	return bits | (1 << 63)
+}
+
+func orderedBits32(f float32) uint32 {
+	bits := math.Float32bits(f)
+	if bits&(1<<31) != 0 {
+		return ^bits
+	}
+	return bits | (1 << 31)
+}
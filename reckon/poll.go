@@ -0,0 +1,94 @@
+package reckon
+
+import (
+	"../clock"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+type poller struct {
+	fn           func() interface{}
+	window       time.Duration
+	interval     time.Duration
+	clock        clock.Clock
+	consistently bool
+}
+
+func Eventually(fn func() interface{}, timeout, interval time.Duration) *reckoning {
+	return newReckoning(nil, &poller{fn, timeout, interval, clock.Real(), false})
+}
+
+func Consistently(fn func() interface{}, window, interval time.Duration) *reckoning {
+	return newReckoning(nil, &poller{fn, window, interval, clock.Real(), true})
+}
+
+func (r *Reckoner) Eventually(fn func() interface{}, timeout, interval time.Duration) *reckoning {
+	r.helper()
+	return newReckoning(r, &poller{fn, timeout, interval, r.clockOrReal(), false})
+}
+
+func (r *Reckoner) Consistently(fn func() interface{}, window, interval time.Duration) *reckoning {
+	r.helper()
+	return newReckoning(r, &poller{fn, window, interval, r.clockOrReal(), true})
+}
+
+func (r *Reckoner) clockOrReal() clock.Clock {
+	if r == nil || r.clock == nil {
+		return clock.Real()
+	}
+	return r.clock
+}
+
+func (r *Reckoner) poll(p *poller, name string, state bool, params []interface{}) {
+	r.helper()
+	exp, ok := expectations.lookup(name)
+	if !ok {
+		return
+	}
+	message := p.run(func(value interface{}) string {
+		return exp.evaluate(state, append([]interface{}{value}, params...))
+	})
+	if len(message) > 0 {
+		r.fail(message)
+	}
+}
+
+func (p *poller) run(evaluate func(value interface{}) string) string {
+	start := p.clock.Now()
+	attempts := 0
+	for {
+		attempts++
+		value := p.fn()
+		message := evaluate(value)
+		elapsed := p.clock.Since(start)
+		if p.consistently && len(message) > 0 {
+			return fmt.Sprintf("Consistently failed after %v attempts: %v\n\tLast value: %v", attempts, message, value)
+		}
+		if !p.consistently && len(message) == 0 {
+			return ""
+		}
+		if elapsed >= p.window {
+			if p.consistently {
+				return ""
+			}
+			return fmt.Sprintf("Eventually failed after %v attempts: %v\n\tLast value: %v", attempts, message, value)
+		}
+		p.clock.Sleep(p.interval)
+	}
+}
+
+func (p *poller) mapped(fn func(value interface{}) interface{}) *poller {
+	inner := p.fn
+	return &poller{func() interface{} {
+		return fn(inner())
+	}, p.window, p.interval, p.clock, p.consistently}
+}
+
+func lengthOf(actual interface{}) interface{} {
+	value := reflect.ValueOf(actual)
+	if value.Kind() == reflect.Slice || value.Kind() == reflect.Array {
+		return value.Len()
+	}
+	return actual
+}
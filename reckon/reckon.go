@@ -5,19 +5,32 @@ import (
 )
 
 func That(actual interface{}) *reckoning {
+	return newReckoning(nil, actual)
+}
+
+func newReckoning(r *Reckoner, actual interface{}) *reckoning {
 	return &reckoning{
-		newIs(actual),
-		newDoes(actual),
-		newHas(actual),
-		newWill(actual),
+		Is:     newIs(r, actual),
+		Does:   newDoes(r, actual),
+		Has:    newHas(r, actual),
+		Will:   newWill(r, actual),
+		r:      r,
+		actual: actual,
 	}
 }
 
 type reckoning struct {
-	Is   *is
-	Does *does
-	Has  *has
-	Will *will
+	Is     *is
+	Does   *does
+	Has    *has
+	Will   *will
+	r      *Reckoner
+	actual interface{}
+}
+
+func (r *reckoning) Satisfies(name string, params ...interface{}) {
+	r.r.helper()
+	r.r.satisfy(name, true, r.actual, params)
 }
 
 type does struct {
@@ -25,28 +38,37 @@ type does struct {
 	Not *objContains
 }
 
-func newDoes(actual interface{}) *does {
+func newDoes(r *Reckoner, actual interface{}) *does {
 	return &does{
-		objContains: &objContains{actual: actual, state: true},
-		Not:         &objContains{actual: actual, state: false},
+		objContains: &objContains{r: r, actual: actual, state: true},
+		Not:         &objContains{r: r, actual: actual, state: false},
 	}
 }
 
 type objContains struct {
+	r      *Reckoner
 	actual interface{}
 	state  bool
 }
 
 func (o *objContains) Exist() {
-	expectations.check("exists", o.state, o.actual)
+	o.r.helper()
+	o.r.check("exists", o.state, o.actual)
 }
 
 func (o *objContains) Match(regex string) {
-	expectations.check("matches", o.state, o.actual, regex)
+	o.r.helper()
+	o.r.check("matches", o.state, o.actual, regex)
 }
 
 func (o *objContains) Contain(needle string) {
-	expectations.check("contains", o.state, o.actual, needle)
+	o.r.helper()
+	o.r.check("contains", o.state, o.actual, needle)
+}
+
+func (o *objContains) ContainElement(expected interface{}) {
+	o.r.helper()
+	o.r.check("contains element", o.state, o.actual, expected)
 }
 
 // Contains
@@ -56,62 +78,132 @@ type is struct {
 	Not *objCompare
 }
 
-func newIs(actual interface{}) *is {
+func newIs(r *Reckoner, actual interface{}) *is {
 	return &is{
-		objCompare: &objCompare{numberCompare: &numberCompare{actual, true}, actual: actual, state: true},
-		Not:        &objCompare{numberCompare: &numberCompare{actual, false}, actual: actual, state: false},
+		objCompare: &objCompare{numberCompare: &numberCompare{r, actual, true}, r: r, actual: actual, state: true},
+		Not:        &objCompare{numberCompare: &numberCompare{r, actual, false}, r: r, actual: actual, state: false},
 	}
 }
 
 type objCompare struct {
 	*numberCompare
+	r      *Reckoner
 	actual interface{}
 	state  bool
 }
 
 func (o *objCompare) EqualTo(expected interface{}) {
-	expectations.check("equals", o.state, o.actual, expected)
+	o.r.helper()
+	o.r.check("equals", o.state, o.actual, expected)
 }
 
 func (o *objCompare) Nil() {
+	o.r.helper()
 	o.EqualTo(nil)
 }
 
 func (o *objCompare) True() {
+	o.r.helper()
 	o.EqualTo(true)
 }
 
 func (o *objCompare) False() {
+	o.r.helper()
 	o.EqualTo(false)
 }
 
+func (o *objCompare) Satisfying(name string, params ...interface{}) {
+	o.r.helper()
+	o.r.satisfy(name, o.state, o.actual, params)
+}
+
+func (o *objCompare) EquivalentTo(expected interface{}) {
+	o.r.helper()
+	o.r.check("is equivalent to", o.state, o.actual, expected)
+}
+
+func (o *objCompare) Sorted(less ...interface{}) {
+	o.r.helper()
+	var by interface{}
+	if len(less) > 0 {
+		by = less[0]
+	}
+	o.r.check("is sorted", o.state, o.actual, by)
+}
+
+func (o *objCompare) ErrorMatching(target error) {
+	o.r.helper()
+	o.r.check("error matching", o.state, o.actual, target)
+}
+
+func (o *objCompare) ErrorOfType(target interface{}) {
+	o.r.helper()
+	o.r.check("error of type", o.state, o.actual, target)
+}
+
+func (o *objCompare) NilError() {
+	o.r.helper()
+	o.r.check("nil error", o.state, o.actual)
+}
+
 func (o *objCompare) Zero() {
-	expectations.check("is zero", o.state, o.actual)
+	o.r.helper()
+	o.r.check("is zero", o.state, o.actual)
 }
 
 func (o *objCompare) A(kind reflect.Kind) {
-	expectations.check("is a", o.state, o.actual, kind)
+	o.r.helper()
+	o.r.check("is a", o.state, o.actual, kind)
 }
 
 func (o *objCompare) AnInstanceOf(t reflect.Type) {
-	expectations.check("instance of", o.state, o.actual, t)
+	o.r.helper()
+	o.r.check("instance of", o.state, o.actual, t)
 }
 
 type numberCompare struct {
+	r      *Reckoner
 	actual interface{}
 	state  bool
 }
 
-func (n *numberCompare) GreaterThan(bound float64) {
-	expectations.check("greater than", n.state, n.actual, bound)
+func (n *numberCompare) GreaterThan(bound interface{}) {
+	n.r.helper()
+	n.r.check("greater than", n.state, n.actual, bound)
 }
 
-func (n *numberCompare) LessThan(bound float64) {
-	expectations.check("less than", n.state, n.actual, bound)
+func (n *numberCompare) LessThan(bound interface{}) {
+	n.r.helper()
+	n.r.check("less than", n.state, n.actual, bound)
 }
 
-func (n *numberCompare) Within(low float64, high float64) {
-	expectations.check("within", n.state, n.actual, low, high)
+func (n *numberCompare) AtLeast(bound interface{}) {
+	n.r.helper()
+	n.r.check("at least", n.state, n.actual, bound)
+}
+
+func (n *numberCompare) AtMost(bound interface{}) {
+	n.r.helper()
+	n.r.check("at most", n.state, n.actual, bound)
+}
+
+func (n *numberCompare) Within(low interface{}, high interface{}, bounds ...Bounds) {
+	n.r.helper()
+	option := Exclusive
+	if len(bounds) > 0 {
+		option = bounds[0]
+	}
+	n.r.check("within", n.state, n.actual, low, high, option)
+}
+
+func (n *numberCompare) CloseTo(expected interface{}, epsilon interface{}) {
+	n.r.helper()
+	n.r.check("close to", n.state, n.actual, expected, epsilon)
+}
+
+func (n *numberCompare) CloseToULP(expected interface{}, ulps uint64) {
+	n.r.helper()
+	n.r.check("close to ulp", n.state, n.actual, expected, ulps)
 }
 
 type has struct {
@@ -122,35 +214,78 @@ type has struct {
 	Length *length
 }
 
-func newHas(actual interface{}) *has {
+func newHas(r *Reckoner, actual interface{}) *has {
 	return &has{
-		owner:  &owner{actual, true},
-		No:     &owner{actual, false},
-		Any:    &listing{actual, true},
-		All:    &listing{actual, false},
-		Length: newLength(actual),
+		owner:  &owner{r, actual, true},
+		No:     &owner{r, actual, false},
+		Any:    &listing{r, actual, true},
+		All:    &listing{r, actual, false},
+		Length: newLength(r, actual),
 	}
 }
 
 type owner struct {
+	r      *Reckoner
 	actual interface{}
 	state  bool
 }
 
 func (o *owner) Property(name interface{}, values ...interface{}) {
+	o.r.helper()
 	o.getProp("has property", name, values)
 }
 
 func (o *owner) DeepProperty(name string, values ...interface{}) {
+	o.r.helper()
 	o.getProp("has deep property", name, values)
 }
 
+func (o *owner) AllElements(predicate interface{}) {
+	o.r.helper()
+	o.r.check("has all elements", o.state, o.actual, predicate)
+}
+
+func (o *owner) AnyElement(predicate interface{}) {
+	o.r.helper()
+	o.r.check("has any element", o.state, o.actual, predicate)
+}
+
+func (o *owner) Unique() {
+	o.r.helper()
+	o.r.check("has unique", o.state, o.actual)
+}
+
+func (o *owner) Subset(subset interface{}) {
+	o.r.helper()
+	o.r.check("has subset", o.state, o.actual, subset)
+}
+
+func (o *owner) ErrorMessage(pattern interface{}) {
+	o.r.helper()
+	o.r.check("has error message", o.state, o.actual, pattern)
+}
+
+func (o *owner) Exactly(count int) *exactly {
+	return &exactly{o, count}
+}
+
+type exactly struct {
+	owner *owner
+	count int
+}
+
+func (e *exactly) ElementsMatching(predicate interface{}) {
+	e.owner.r.helper()
+	e.owner.r.check("has exactly matching", e.owner.state, e.owner.actual, e.count, predicate)
+}
+
 func (o *owner) getProp(fn string, name interface{}, values []interface{}) {
+	o.r.helper()
 	params := append([]interface{}{}, o.actual, name)
 	for _, value := range values {
 		params = append(params, value)
 	}
-	expectations.check(fn, o.state, params...)
+	o.r.check(fn, o.state, params...)
 }
 
 type length struct {
@@ -158,35 +293,38 @@ type length struct {
 	Not *numberCompare
 }
 
-func newLength(actual interface{}) *length {
-	temp := actual
-	value := reflect.ValueOf(actual)
-	if value.Kind() == reflect.Slice || value.Kind() == reflect.Array {
-		temp = value.Len()
+func newLength(r *Reckoner, actual interface{}) *length {
+	temp := lengthOf(actual)
+	if p, ok := actual.(*poller); ok {
+		temp = p.mapped(lengthOf)
 	}
 	return &length{
-		numberCompare: &numberCompare{temp, true},
-		Not:           &numberCompare{temp, false},
+		numberCompare: &numberCompare{r, temp, true},
+		Not:           &numberCompare{r, temp, false},
 	}
 }
 
 type listing struct {
+	r      *Reckoner
 	actual interface{}
 	state  bool
 }
 
 func (l *listing) Keys(names ...interface{}) {
+	l.r.helper()
 	l.getList("has keys", names)
 }
 
 func (l *listing) Properties(names ...interface{}) {
+	l.r.helper()
 	l.getList("has properties", names)
 }
 
 func (l *listing) getList(fn string, names []interface{}) {
+	l.r.helper()
 	params := append([]interface{}{}, l.actual, l.state)
 	params = append(params, names...)
-	expectations.check(fn, true, params...)
+	l.r.check(fn, true, params...)
 }
 
 type will struct {
@@ -194,22 +332,25 @@ type will struct {
 	Not *panicCheck
 }
 
-func newWill(actual interface{}) *will {
+func newWill(r *Reckoner, actual interface{}) *will {
 	return &will{
-		panicCheck: &panicCheck{actual, true},
-		Not:        &panicCheck{actual, false},
+		panicCheck: &panicCheck{r, actual, true},
+		Not:        &panicCheck{r, actual, false},
 	}
 }
 
 type panicCheck struct {
+	r      *Reckoner
 	actual interface{}
 	state  bool
 }
 
 func (p *panicCheck) Panic() {
-	expectations.check("panics", p.state, p.actual)
+	p.r.helper()
+	p.r.check("panics", p.state, p.actual)
 }
 
 func (p *panicCheck) PanicWith(message interface{}) {
-	expectations.check("panics with message", p.state, p.actual, message)
+	p.r.helper()
+	p.r.check("panics with message", p.state, p.actual, message)
 }
@@ -2,12 +2,21 @@ package reckon_test
 
 import (
 	"."
+	"../clock"
+	"../common"
 	"../suiteshop"
 
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
 	"reflect"
+	"regexp"
 	"strings"
 	"testing"
+	"time"
 )
 
 func Test(t *testing.T) {
@@ -492,9 +501,643 @@ func Test(t *testing.T) {
 				})
 			})
 		})
+		suite.Describe("Reckoner", func(suite *suiteshop.Suite) {
+			suite.Test("reports errors", func(log *suiteshop.Log) {
+				reporter := &fakeReporter{errors: []string{}, fatals: []string{}}
+				r := reckon.New(reporter)
+				r.That(4).Is.EqualTo(4)
+				r.That(4).Is.EqualTo(5)
+				r.That("abc").Does.Not.Contain("b")
+				r.That(struct{ A int }{A: 3}).Has.Property("B")
+				r.That(func() {}).Will.PanicWith("boom")
+				reckon.That(reporter.errors).Is.EqualTo([]string{
+					"Items not equal:\n\tActual: 4\n\tExpected: 5",
+					"Does contain string",
+					"Does not have property",
+					"Has not panicked",
+				})
+				reckon.That(reporter.fatals).Is.EqualTo([]string{})
+				reckon.That(reporter.helpers > 0).Is.True()
+			})
+			suite.Test("require is fatal", func(log *suiteshop.Log) {
+				reporter := &fakeReporter{errors: []string{}, fatals: []string{}}
+				r := reckon.With(reporter)
+				r.Require.That(3).Is.GreaterThan(1)
+				r.Require.That(nil).Is.Not.Nil()
+				reckon.That(reporter.errors).Is.EqualTo([]string{})
+				reckon.That(reporter.fatals).Is.EqualTo([]string{"Items equal:\n\tActual: <nil>\n\tExpected: <nil>"})
+			})
+		})
+		suite.Describe("Soft", func(suite *suiteshop.Suite) {
+			suite.Test("collects failures", func(log *suiteshop.Log) {
+				reckon.That(func() {
+					reckon.Soft(func(r *reckon.Reckoner) {
+						r.That(1).Is.EqualTo(2)
+						r.That(true).Is.True()
+						r.Require.That("abc").Does.Contain("d")
+						r.That(3).Is.EqualTo(4)
+					})
+				}).Will.PanicWith("2 soft expectations failed:\n1) Items not equal:\n\tActual: 1\n\tExpected: 2\n2) Does not contain string")
+				reckon.That(func() {
+					reckon.Soft(func(r *reckon.Reckoner) {
+						panic("unrelated")
+					})
+				}).Will.PanicWith("unrelated")
+				reckon.That(func() {
+					reckon.Soft(func(r *reckon.Reckoner) {
+						r.That(1).Is.EqualTo(1)
+					})
+				}).Will.Not.Panic()
+			})
+			suite.Test("reports through the reckoner", func(log *suiteshop.Log) {
+				reporter := &fakeReporter{errors: []string{}, fatals: []string{}}
+				reckon.New(reporter).Require.Soft(func(r *reckon.Reckoner) {
+					r.That(1).Is.LessThan(0)
+					r.That([]int{1}).Has.Length.GreaterThan(2)
+				})
+				reckon.That(reporter.errors).Is.EqualTo([]string{"Is not less than", "Is not greater than"})
+				reckon.That(reporter.fatals).Is.EqualTo([]string{"2 soft expectations failed"})
+			})
+			suite.Test("attaches to suiteshop logs", func(log *suiteshop.Log) {
+				messages := []string{}
+				hasErrors := suiteshop.Describe("Inner", func(suite *suiteshop.Suite) {
+					suite.Test("fields", func(log *suiteshop.Log) {
+						reckon.New(log).Soft(func(r *reckon.Reckoner) {
+							r.That("a").Is.EqualTo("b")
+							r.That(3).Is.GreaterThan(5)
+						})
+						log.Info("body finished")
+					})
+				}).Post(func(message string) {
+					messages = append(messages, message)
+				})
+				report := strings.Join(messages, "\n")
+				reckon.That(hasErrors).Is.True()
+				reckon.That(report).Does.Contain("body finished")
+				reckon.That(report).Does.Contain("Inner - fields -- Items not equal:\n\tActual: a\n\tExpected: b\nIs not greater than")
+			})
+		})
+		suite.Describe("Diff", func(suite *suiteshop.Suite) {
+			suite.Test("structs and slices", func(log *suiteshop.Log) {
+				actual := directory{[]user{{"ann", address{"12345"}}, {"bob", address{"12345"}}}}
+				expected := directory{[]user{{"ann", address{"12345"}}, {"bob", address{"12346"}}, {"cy", address{""}}}}
+				reckon.That(func() {
+					reckon.That(&actual).Is.EqualTo(&expected)
+				}).Will.PanicWith("Items not equal:\n\t.Users: length 2 != 3\n\t.Users[1].Address.Zip: \"12345\" != \"12346\"")
+			})
+			suite.Test("maps", func(log *suiteshop.Log) {
+				reckon.That(func() {
+					reckon.That(map[string]int{"a": 1, "b": 2, "d": 4}).Is.EqualTo(map[string]int{"a": 1, "b": 3, "c": 3})
+				}).Will.PanicWith("Items not equal:\n\t[\"b\"]: 2 != 3\n\t[\"c\"]: missing != 3\n\t[\"d\"]: 4 != missing")
+			})
+			suite.Test("strings", func(log *suiteshop.Log) {
+				reckon.That(func() {
+					reckon.That("one\ntwo\nthree").Is.EqualTo("one\n2\nthree")
+				}).Will.PanicWith("Items not equal:\n\t--- expected\n\t+++ actual\n\t one\n\t-2\n\t+two\n\t three")
+				reckon.That(func() {
+					reckon.That("abc").Is.EqualTo("abd")
+				}).Will.PanicWith("Items not equal:\n\tActual: abc\n\tExpected: abd")
+			})
+			suite.Test("string context", func(log *suiteshop.Log) {
+				actual := []string{}
+				expected := []string{}
+				for index := 1; index <= 60; index++ {
+					actual = append(actual, fmt.Sprintf("line %v", index))
+					expected = append(expected, fmt.Sprintf("line %v", index))
+				}
+				actual[40] = "changed"
+				reckon.That(func() {
+					reckon.That(strings.Join(actual, "\n")).Is.EqualTo(strings.Join(expected, "\n"))
+				}).Will.PanicWith(strings.Join([]string{
+					"Items not equal:",
+					"--- expected",
+					"+++ actual",
+					"...",
+					" line 38",
+					" line 39",
+					" line 40",
+					"-line 41",
+					"+changed",
+					" line 42",
+					" line 43",
+					" line 44",
+					"...",
+				}, "\n\t"))
+				for index := range actual {
+					actual[index] = fmt.Sprintf("other %v", index)
+				}
+				lines := []string{"Items not equal:", "--- expected", "+++ actual"}
+				for index := 1; index <= 20; index++ {
+					lines = append(lines, fmt.Sprintf("-line %v", index))
+				}
+				lines = append(lines, "... and 100 more differences")
+				reckon.That(func() {
+					reckon.That(strings.Join(actual, "\n")).Is.EqualTo(strings.Join(expected, "\n"))
+				}).Will.PanicWith(strings.Join(lines, "\n\t"))
+			})
+			suite.Test("large strings", func(log *suiteshop.Log) {
+				actual := []string{}
+				expected := []string{}
+				for index := 0; index < 2000; index++ {
+					actual = append(actual, fmt.Sprintf("line %v", index))
+					expected = append(expected, fmt.Sprintf("line %v", index))
+				}
+				actual[1500] = strings.Repeat("a", 100)
+				reckon.That(func() {
+					reckon.That(strings.Join(actual, "\n")).Is.EqualTo(strings.Join(expected, "\n"))
+				}).Will.PanicWith("Items not equal:\n\t--- expected\n\t+++ actual\n\tfirst difference at line 1501 (2000 expected, 2000 actual lines)\n\t-\"line 1500\"\n\t+\"" + strings.Repeat("a", 76) + "...")
+			})
+			suite.Test("truncation and color", func(log *suiteshop.Log) {
+				long := strings.Repeat("x", 100)
+				reckon.That(func() {
+					reckon.That([]string{long}).Is.EqualTo([]string{"y"})
+				}).Will.PanicWith("Items not equal:\n\t[0]: \"" + strings.Repeat("x", 76) + "... != \"y\"")
+				reckon.That(func() {
+					reckon.That(make([]int, 25)).Is.EqualTo(make([]int, 0))
+				}).Will.PanicWith("Items not equal:\n\t.: length 25 != 0")
+				many := make([]int, 25)
+				lines := []string{"Items not equal:"}
+				for index := range many {
+					many[index] = index + 1
+					if index < 20 {
+						lines = append(lines, fmt.Sprintf("[%v]: %v != 0", index, index+1))
+					}
+				}
+				lines = append(lines, "... and 5 more differences")
+				reckon.That(func() {
+					reckon.That(many).Is.EqualTo(make([]int, 25))
+				}).Will.PanicWith(strings.Join(lines, "\n\t"))
+				reckon.UseColor(true)
+				defer reckon.UseColor(false)
+				reckon.That(func() {
+					reckon.That([]int{1}).Is.EqualTo([]int{2})
+				}).Will.PanicWith("Items not equal:\n\t[0]: \x1b[31m1\x1b[0m != \x1b[32m2\x1b[0m")
+			})
+		})
+		suite.Describe("Register", func(suite *suiteshop.Suite) {
+			suite.Test("custom expectations", func(log *suiteshop.Log) {
+				err := reckon.Register("valid order", reckon.Expectation{
+					Message:    "Is not a valid order: %v",
+					NotMessage: "Is a valid order: %v",
+					Params:     []int{0},
+					Condition: func(args *common.Args) bool {
+						return args.Get(0).Int() > 0 && args.Get(0).Int() <= args.Get(1).Int()
+					},
+				})
+				reckon.That(err).Is.Nil()
+				reckon.That(4).Satisfies("valid order", 10)
+				reckon.That(40).Is.Not.Satisfying("valid order", 10)
+				reckon.That(func() {
+					reckon.That(40).Satisfies("valid order", 10)
+				}).Will.PanicWith("Is not a valid order: 40")
+				reckon.That(func() {
+					reckon.That(4).Is.Not.Satisfying("valid order", 10)
+				}).Will.PanicWith("Is a valid order: 4")
+			})
+			suite.Test("formatters", func(log *suiteshop.Log) {
+				err := reckon.Register("even", reckon.Expectation{
+					Message:    "Is not even",
+					NotMessage: "Is even",
+					Condition: func(args *common.Args) bool {
+						return args.Get(0).Int()%2 == 0
+					},
+					Format: func(state bool, args *common.Args) string {
+						return fmt.Sprintf("%v has remainder %v", args.Get(0).Int(), args.Get(0).Int()%2)
+					},
+				})
+				reckon.That(err).Is.Nil()
+				reckon.That(func() {
+					reckon.That(3).Is.Satisfying("even")
+				}).Will.PanicWith("3 has remainder 1")
+			})
+			suite.Test("collisions and unknown names", func(log *suiteshop.Log) {
+				condition := func(args *common.Args) bool { return true }
+				reckon.That(reckon.Register("equals", reckon.Expectation{Condition: condition}).Error()).Is.EqualTo("expectation already registered: equals")
+				reckon.That(reckon.Register("no condition", reckon.Expectation{}).Error()).Is.EqualTo("expectation has no condition: no condition")
+				reckon.That(func() {
+					reckon.That(1).Satisfies("nonexistent")
+				}).Will.PanicWith("Unknown expectation: nonexistent")
+				reporter := &fakeReporter{errors: []string{}, fatals: []string{}}
+				reckon.New(reporter).That(1).Is.Not.Satisfying("nonexistent")
+				reckon.That(reporter.errors).Is.EqualTo([]string{"Unknown expectation: nonexistent"})
+			})
+		})
+		suite.Describe("Collections", func(suite *suiteshop.Suite) {
+			suite.Test("contain element", func(log *suiteshop.Log) {
+				reckon.That([]int{1, 2, 3, 2}).Does.ContainElement(2)
+				reckon.That(map[string]int{"a": 1}).Does.ContainElement(1)
+				reckon.That([]int{1, 2}).Does.Not.ContainElement(5)
+				reckon.That(func() {
+					reckon.That([]int{1, 2}).Does.ContainElement(5)
+				}).Will.PanicWith("Does not contain element: 5")
+				reckon.That(func() {
+					reckon.That([]int{1, 2, 3, 2}).Does.Not.ContainElement(2)
+				}).Will.PanicWith("Does contain element: 2 at [1], [3]")
+				reckon.That(func() {
+					reckon.That(5).Does.ContainElement(5)
+				}).Will.PanicWith("Value is not a collection")
+			})
+			suite.Test("predicates", func(log *suiteshop.Log) {
+				positive := func(n int) bool { return n > 0 }
+				reckon.That([]int{1, 2, 3}).Has.AllElements(positive)
+				reckon.That([]int{-1, 2}).Has.AnyElement(positive)
+				reckon.That([]int{-1, -2}).Has.No.AnyElement(positive)
+				reckon.That([]interface{}{"a", "bb"}).Has.AllElements(common.Regex("^[a-z]+$"))
+				reckon.That(func() {
+					reckon.That([]int{1, -2, 3, -4}).Has.AllElements(positive)
+				}).Will.PanicWith("Elements do not match: [1]: -2, [3]: -4")
+				reckon.That(func() {
+					reckon.That([]int{-1, 2}).Has.No.AnyElement(positive)
+				}).Will.PanicWith("Elements match: [1]: 2")
+				reckon.That(func() {
+					reckon.That([]int{-1}).Has.AnyElement(positive)
+				}).Will.PanicWith("No element matches")
+				reckon.That(func() {
+					reckon.That([]string{"a"}).Has.AnyElement(positive)
+				}).Will.PanicWith("Predicate expects int, got string")
+				reckon.That([]int{1, 1, 2}).Has.Exactly(2).ElementsMatching(1)
+				reckon.That([]int{1, 1, 2}).Has.No.Exactly(1).ElementsMatching(1)
+				reckon.That(func() {
+					reckon.That([]int{1, 5, 7}).Has.Exactly(1).ElementsMatching(common.Range(4, 10))
+				}).Will.PanicWith("Has 2 matching elements, expected 1: [1]: 5, [2]: 7")
+			})
+			suite.Test("equivalence and subsets", func(log *suiteshop.Log) {
+				reckon.That([]int{3, 1, 2, 1}).Is.EquivalentTo([]int{1, 1, 2, 3})
+				reckon.That([]int{1, 2}).Is.Not.EquivalentTo([]int{1, 2, 2})
+				reckon.That(func() {
+					reckon.That([]string{"a", "b", "b"}).Is.EquivalentTo([]string{"b", "c", "a"})
+				}).Will.PanicWith("Items not equivalent:\n\tMissing: [1]: \"c\"\n\tExtra: [2]: \"b\"")
+				reckon.That([]int{1, 2, 3}).Has.Subset([]int{3, 1})
+				reckon.That(func() {
+					reckon.That([]int{1, 2, 3}).Has.Subset([]int{3, 4, 5})
+				}).Will.PanicWith("Does not have subset, missing: [1]: 4, [2]: 5")
+				reckon.That(func() {
+					reckon.That([]int{1, 2, 3}).Has.No.Subset([]int{1})
+				}).Will.PanicWith("Does have subset")
+			})
+			suite.Test("ordering and uniqueness", func(log *suiteshop.Log) {
+				reckon.That([]int{1, 2, 2, 5}).Is.Sorted()
+				reckon.That([]string{"c", "b", "a"}).Is.Sorted(func(a, b string) bool { return a > b })
+				reckon.That([]int{2, 1}).Is.Not.Sorted()
+				reckon.That([]int64{1<<53 + 1, 1 << 53}).Is.Not.Sorted()
+				reckon.That([]uint64{1<<63 + 1, 1<<63 + 2}).Is.Sorted()
+				reckon.That([]interface{}{1, 2.5, uint8(3)}).Is.Sorted()
+				reckon.That(func() {
+					reckon.That([]int{1, 5, 3}).Is.Sorted()
+				}).Will.PanicWith("Is not sorted: [1]: 5 before [2]: 3")
+				reckon.That([]int{1, 2, 3}).Has.Unique()
+				reckon.That([]int{1, 1}).Has.No.Unique()
+				reckon.That(func() {
+					reckon.That([]int{1, 2, 1, 2, 3}).Has.Unique()
+				}).Will.PanicWith("Has duplicate elements: [2]: 1, [3]: 2")
+				reckon.That(func() {
+					reckon.That([]int{1, 2}).Has.No.Unique()
+				}).Will.PanicWith("All elements are unique")
+			})
+		})
+		suite.Describe("Errors", func(suite *suiteshop.Suite) {
+			notFound := errors.New("not found")
+			suite.Test("matching", func(log *suiteshop.Log) {
+				err := fmt.Errorf("loading user: %w", notFound)
+				reckon.That(err).Is.ErrorMatching(notFound)
+				reckon.That(err).Is.Not.ErrorMatching(os.ErrClosed)
+				reckon.That(func() {
+					reckon.That(err).Is.ErrorMatching(os.ErrClosed)
+				}).Will.PanicWith("Error does not match file already closed\n\t*fmt.wrapError: loading user: not found\n\t*errors.errorString: not found")
+				reckon.That(func() {
+					reckon.That(5).Is.ErrorMatching(notFound)
+				}).Will.PanicWith("Value is not an error")
+			})
+			suite.Test("types", func(log *suiteshop.Log) {
+				err := fmt.Errorf("opening: %w", &os.PathError{Op: "open", Path: "/tmp/x", Err: notFound})
+				var pathErr *os.PathError
+				reckon.That(err).Is.ErrorOfType(&pathErr)
+				reckon.That(pathErr.Path).Is.EqualTo("/tmp/x")
+				var linkErr *os.LinkError
+				reckon.That(err).Is.Not.ErrorOfType(&linkErr)
+				reckon.That(func() {
+					reckon.That(err).Is.ErrorOfType(&linkErr)
+				}).Will.PanicWith("Error is not of type *os.LinkError\n\t*fmt.wrapError: opening: open /tmp/x: not found\n\t*fs.PathError: open /tmp/x: not found\n\t*errors.errorString: not found")
+				reckon.That(func() {
+					reckon.That(err).Is.ErrorOfType(nil)
+				}).Will.PanicWith("Target must be a non-nil pointer")
+			})
+			suite.Test("messages", func(log *suiteshop.Log) {
+				err := errors.Join(errors.New("first"), fmt.Errorf("second: %w", notFound))
+				reckon.That(err).Has.ErrorMessage("not found")
+				reckon.That(err).Has.ErrorMessage(regexp.MustCompile("^sec"))
+				reckon.That(err).Has.No.ErrorMessage("third")
+				reckon.That(func() {
+					reckon.That(err).Has.ErrorMessage("third")
+				}).Will.PanicWith("No error in chain has message third\n\t*errors.joinError: first\nsecond: not found\n\t*errors.errorString: first\n\t*fmt.wrapError: second: not found\n\t*errors.errorString: not found")
+			})
+			suite.Test("nil", func(log *suiteshop.Log) {
+				var err error
+				reckon.That(err).Is.NilError()
+				reckon.That(notFound).Is.Not.NilError()
+				reckon.That(func() {
+					reckon.That(fmt.Errorf("outer: %w", notFound)).Is.NilError()
+				}).Will.PanicWith("Error is not nil\n\t*fmt.wrapError: outer: not found\n\t*errors.errorString: not found")
+				reckon.That(func() {
+					reckon.That(err).Is.Not.NilError()
+				}).Will.PanicWith("Error is nil")
+			})
+		})
+		suite.Describe("Numbers", func(suite *suiteshop.Suite) {
+			suite.Test("exact comparisons", func(log *suiteshop.Log) {
+				var big int64 = 1<<53 + 1
+				reckon.That(big).Is.GreaterThan(int64(1 << 53))
+				reckon.That(uint64(math.MaxUint64)).Is.GreaterThan(uint64(math.MaxUint64 - 1))
+				reckon.That(uint64(math.MaxUint64 - 1)).Is.LessThan(uint64(math.MaxUint64))
+				reckon.That(3).Is.GreaterThan(2.5)
+				reckon.That(int8(-3)).Is.LessThan(uint(0))
+				reckon.That(math.NaN()).Is.Not.GreaterThan(0)
+				reckon.That(math.NaN()).Is.Not.LessThan(0)
+				reckon.That(func() {
+					reckon.That("abc").Is.GreaterThan(1)
+				}).Will.PanicWith("Value is not a number: abc")
+			})
+			suite.Test("bounds", func(log *suiteshop.Log) {
+				reckon.That(5).Is.AtLeast(5)
+				reckon.That(5).Is.AtMost(5.0)
+				reckon.That(4).Is.Not.AtLeast(5)
+				reckon.That(func() {
+					reckon.That(6).Is.AtMost(5)
+				}).Will.PanicWith("Is not at most 5")
+				reckon.That(5).Is.Not.Within(3, 5)
+				reckon.That(5).Is.Within(3, 5, reckon.Inclusive)
+				reckon.That(3).Is.Within(3, 5, reckon.InclusiveLow)
+				reckon.That(3).Is.Not.Within(3, 5, reckon.InclusiveHigh)
+				reckon.That(uint64(1<<63+1)).Is.Within(uint64(1<<63), uint64(1<<63+2))
+			})
+			suite.Test("tolerance", func(log *suiteshop.Log) {
+				tenth, fifth := 0.1, 0.2
+				sum := tenth + fifth
+				reckon.That(sum).Is.Not.EqualTo(0.3)
+				reckon.That(sum).Is.CloseTo(0.3, 1e-9)
+				reckon.That(sum).Is.CloseToULP(0.3, 1)
+				reckon.That(float32(1)).Is.CloseToULP(float32(1)+1e-7, 1)
+				reckon.That(1.5).Is.Not.CloseTo(1, 0.1)
+				reckon.That(math.Inf(1)).Is.CloseTo(math.Inf(1), 0.1)
+				reckon.That(math.Inf(-1)).Is.CloseTo(math.Inf(-1), 0)
+				reckon.That(math.Inf(1)).Is.Not.CloseTo(math.Inf(-1), 0.1)
+				reckon.That(math.Inf(1)).Is.Not.CloseTo(1e308, 1e308)
+				reckon.That(func() {
+					reckon.That(math.Inf(1)).Is.CloseTo(math.Inf(-1), 0.1)
+				}).Will.PanicWith("Is not close to -Inf ± 0.1: difference +Inf")
+				reckon.That(func() {
+					reckon.That(1.5).Is.CloseTo(1, 0.1)
+				}).Will.PanicWith("Is not close to 1 ± 0.1: difference 0.5")
+				reckon.That(func() {
+					reckon.That(1.0).Is.CloseToULP(1.0000000000000004, 1)
+				}).Will.PanicWith("Is not within 1 ULPs of 1.0000000000000004: distance 2")
+				reckon.That(func() {
+					reckon.That(math.NaN()).Is.CloseToULP(1.0, 1)
+				}).Will.PanicWith("Is not within 1 ULPs of 1: distance NaN")
+			})
+		})
+		suite.Describe("Polling", func(suite *suiteshop.Suite) {
+			start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+			suite.Test("eventually", func(log *suiteshop.Log) {
+				fake := clock.NewFake(start).AutoAdvance()
+				count := 0
+				reckon.WithClock(fake).Eventually(func() interface{} {
+					count++
+					return count
+				}, time.Second, 100*time.Millisecond).Is.EqualTo(3)
+				reckon.That(count).Is.EqualTo(3)
+				reckon.That(fake.Now()).Is.EqualTo(start.Add(200 * time.Millisecond))
+				items := []int{}
+				reckon.WithClock(fake).Eventually(func() interface{} {
+					items = append(items, len(items))
+					return items
+				}, time.Second, 100*time.Millisecond).Has.Length.GreaterThan(2)
+				reckon.That(items).Is.EqualTo([]int{0, 1, 2})
+				reckon.That(func() {
+					reckon.WithClock(fake).Eventually(func() interface{} {
+						return "x"
+					}, time.Second, 100*time.Millisecond).Is.EqualTo("y")
+				}).Will.PanicWith("Eventually failed after 11 attempts: Items not equal:\n\tActual: x\n\tExpected: y\n\tLast value: x")
+			})
+			suite.Test("consistently", func(log *suiteshop.Log) {
+				fake := clock.NewFake(start).AutoAdvance()
+				count := 0
+				reckon.WithClock(fake).Consistently(func() interface{} {
+					count++
+					return count
+				}, 500*time.Millisecond, 100*time.Millisecond).Is.LessThan(10)
+				reckon.That(count).Is.EqualTo(6)
+				reckon.That(func() {
+					reckon.WithClock(fake).Consistently(func() interface{} {
+						count++
+						return count
+					}, time.Second, 100*time.Millisecond).Is.AtMost(8)
+				}).Will.PanicWith("Consistently failed after 3 attempts: Is not at most 8\n\tLast value: 9")
+			})
+			suite.Test("reporting and real clocks", func(log *suiteshop.Log) {
+				reporter := &fakeReporter{errors: []string{}, fatals: []string{}}
+				fake := clock.NewFake(start).AutoAdvance()
+				reckon.New(reporter).WithClock(fake).Require.Eventually(func() interface{} {
+					return false
+				}, 300*time.Millisecond, 100*time.Millisecond).Is.True()
+				reckon.That(reporter.fatals).Is.EqualTo([]string{"Eventually failed after 4 attempts: Items not equal:\n\tActual: false\n\tExpected: true\n\tLast value: false"})
+				ready := make(chan struct{})
+				go func() {
+					time.Sleep(5 * time.Millisecond)
+					close(ready)
+				}()
+				reckon.Eventually(func() interface{} {
+					select {
+					case <-ready:
+						return true
+					default:
+						return false
+					}
+				}, time.Second, time.Millisecond).Is.True()
+			})
+		})
+		suite.Describe("Channels", func(suite *suiteshop.Suite) {
+			suite.Test("receive", func(log *suiteshop.Log) {
+				ch := make(chan string, 2)
+				ch <- "first"
+				ch <- "second"
+				reckon.That(ch).Has.Buffered(2)
+				reckon.That(ch).Will.Receive(time.Second).Is.EqualTo("first")
+				reckon.That(ch).Will.ReceiveValue("second", time.Second)
+				reckon.That(ch).Will.Not.Receive(5 * time.Millisecond)
+				reckon.That(ch).Has.No.Buffered(1)
+				go func() {
+					ch <- "late"
+				}()
+				reckon.That((<-chan string)(ch)).Will.Receive(time.Second).Does.Contain("lat")
+				reckon.That(func() {
+					reckon.That(ch).Will.Receive(5 * time.Millisecond)
+				}).Will.PanicWith("Did not receive within 5ms")
+				ch <- "third"
+				reckon.That(func() {
+					reckon.That(ch).Will.ReceiveValue("fourth", time.Second)
+				}).Will.PanicWith("Items not equal:\n\tActual: third\n\tExpected: fourth")
+				ch <- "fifth"
+				reckon.That(func() {
+					reckon.That(ch).Will.Not.Receive(time.Second)
+				}).Will.PanicWith("Received value: fifth")
+				reckon.That(func() {
+					reckon.That(ch).Has.Buffered(1)
+				}).Will.PanicWith("Has 0 buffered values, expected 1")
+			})
+			suite.Test("closed", func(log *suiteshop.Log) {
+				ch := make(chan int, 1)
+				reckon.That(ch).Is.Not.Closed()
+				ch <- 1
+				close(ch)
+				reckon.That(ch).Is.Not.Closed()
+				reckon.That(ch).Will.ReceiveValue(1, time.Second)
+				reckon.That(ch).Is.Closed()
+				reckon.That(func() {
+					reckon.That(ch).Will.Receive(time.Second)
+				}).Will.PanicWith("Channel is closed")
+				reckon.That(ch).Will.Not.Receive(time.Millisecond)
+			})
+			suite.Test("closed unbuffered", func(log *suiteshop.Log) {
+				ch := make(chan int)
+				reckon.That(ch).Is.Not.Closed().Is.Nil()
+				go func() {
+					ch <- 5
+				}()
+				time.Sleep(10 * time.Millisecond)
+				reckon.That(ch).Is.Not.Closed().Is.EqualTo(5)
+				go func() {
+					ch <- 6
+				}()
+				time.Sleep(10 * time.Millisecond)
+				reckon.That(func() {
+					reckon.That(ch).Is.Closed()
+				}).Will.PanicWith("Is not closed: received 6")
+				close(ch)
+				reckon.That(ch).Is.Closed().Is.Nil()
+				reckon.That(func() {
+					reckon.That(5).Is.Closed()
+				}).Will.PanicWith("Value is not a receivable channel")
+			})
+			suite.Test("clocks and reporters", func(log *suiteshop.Log) {
+				fake := clock.NewFake(time.Now())
+				ch := make(chan int)
+				done := make(chan struct{})
+				go func() {
+					defer close(done)
+					reckon.WithClock(fake).That(ch).Will.Not.Receive(time.Hour)
+				}()
+				fake.BlockUntil(1)
+				fake.Advance(time.Hour)
+				<-done
+				reporter := &fakeReporter{errors: []string{}, fatals: []string{}}
+				reckon.New(reporter).That(5).Will.Not.Receive(time.Second)
+				reckon.New(reporter).That(make(chan<- int)).Will.ReceiveValue(1, time.Second)
+				reckon.That(reporter.errors).Is.EqualTo([]string{"Value is not a receivable channel", "Value is not a receivable channel"})
+			})
+		})
+		suite.Describe("Snapshots", func(suite *suiteshop.Suite) {
+			suite.Test("match and update", func(log *suiteshop.Log) {
+				dir, err := ioutil.TempDir("", "snapshots")
+				reckon.That(err).Is.Nil()
+				defer os.RemoveAll(dir)
+				reckon.UseSnapshotDir(dir)
+				defer reckon.UseSnapshotDir("__snapshots__")
+				reporter := &namedReporter{&fakeReporter{errors: []string{}, fatals: []string{}}, "TestSnap/case 1"}
+				value := map[string]interface{}{"name": "ann", "tags": []string{"a", "b"}}
+				reckon.That(value).MatchesSnapshot(reporter, "user")
+				data, err := ioutil.ReadFile(filepath.Join(dir, "TestSnap_case_1.user.snap"))
+				reckon.That(err).Is.Nil()
+				reckon.That(string(data)).Is.EqualTo("{\n  \"name\": \"ann\",\n  \"tags\": [\n    \"a\",\n    \"b\"\n  ]\n}\n")
+				reckon.That(value).MatchesSnapshot(reporter, "user")
+				reckon.That("line one\nline two").MatchesSnapshot(reporter, "text")
+				reckon.That([]byte("line one\nline 2")).MatchesSnapshot(reporter, "text")
+				reckon.That(reporter.errors).Is.EqualTo([]string{
+					"Snapshot text does not match (set CLOUSEAU_UPDATE=1 to update):\n\t--- expected\n\t+++ actual\n\t line one\n\t-line two\n\t+line 2",
+				})
+				os.Setenv("CLOUSEAU_UPDATE", "1")
+				defer os.Unsetenv("CLOUSEAU_UPDATE")
+				reckon.That("line one\nline 2").MatchesSnapshot(reporter, "text")
+				data, _ = ioutil.ReadFile(filepath.Join(dir, "TestSnap_case_1.text.snap"))
+				reckon.That(string(data)).Is.EqualTo("line one\nline 2")
+				reckon.That(len(reporter.errors)).Is.EqualTo(1)
+			})
+			suite.Test("obsolete", func(log *suiteshop.Log) {
+				dir, err := ioutil.TempDir("", "snapshots")
+				reckon.That(err).Is.Nil()
+				defer os.RemoveAll(dir)
+				reckon.UseSnapshotDir(dir)
+				defer reckon.UseSnapshotDir("__snapshots__")
+				stale := filepath.Join(dir, "TestStale.old.snap")
+				other := filepath.Join(dir, "TestOther.old.snap")
+				ioutil.WriteFile(stale, []byte("old"), 0644)
+				ioutil.WriteFile(other, []byte("old"), 0644)
+				ioutil.WriteFile(filepath.Join(dir, "unnamed.snap"), []byte("old"), 0644)
+				ioutil.WriteFile(filepath.Join(dir, "notes.txt"), []byte("keep"), 0644)
+				reporter := &namedReporter{&fakeReporter{errors: []string{}, fatals: []string{}}, "TestStale"}
+				reckon.That("current").MatchesSnapshot(reporter, "current")
+				reckon.CheckSnapshots(reporter)
+				reckon.That(reporter.errors).Is.EqualTo([]string{"Obsolete snapshot (set CLOUSEAU_PRUNE=1 to remove): " + stale})
+				os.Setenv("CLOUSEAU_UPDATE", "1")
+				reckon.CheckSnapshots(reporter)
+				os.Unsetenv("CLOUSEAU_UPDATE")
+				_, err = os.Stat(stale)
+				reckon.That(err).Is.Nil()
+				os.Setenv("CLOUSEAU_PRUNE", "1")
+				defer os.Unsetenv("CLOUSEAU_PRUNE")
+				reckon.CheckSnapshots(reporter)
+				_, err = os.Stat(stale)
+				reckon.That(os.IsNotExist(err)).Is.True()
+				_, err = os.Stat(other)
+				reckon.That(err).Is.Nil()
+				obsolete, err := reckon.ObsoleteSnapshots()
+				reckon.That(err).Is.Nil()
+				reckon.That(obsolete).Is.EqualTo([]string{})
+			})
+		})
 	}).Post(fn) {
 		t.Fatal(strings.Join(list, "\n"))
 	} else {
 		fmt.Println(strings.Join(list, "\n"))
 	}
 }
+
+type address struct {
+	Zip string
+}
+
+type user struct {
+	Name    string
+	Address address
+}
+
+type directory struct {
+	Users []user
+}
+
+type namedReporter struct {
+	*fakeReporter
+	name string
+}
+
+func (r *namedReporter) Name() string {
+	return r.name
+}
+
+type fakeReporter struct {
+	helpers int
+	errors  []string
+	fatals  []string
+}
+
+func (r *fakeReporter) Helper() {
+	r.helpers++
+}
+
+func (r *fakeReporter) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *fakeReporter) Fatalf(format string, args ...interface{}) {
+	r.fatals = append(r.fatals, fmt.Sprintf(format, args...))
+}
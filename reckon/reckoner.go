@@ -0,0 +1,169 @@
+package reckon
+
+import (
+	"../clock"
+	"fmt"
+	"strings"
+)
+
+type Reporter interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+	Fatalf(format string, args ...interface{})
+}
+
+type Reckoner struct {
+	reporter Reporter
+	fatal    bool
+	Require  *Reckoner
+	clock    clock.Clock
+}
+
+func New(t Reporter) *Reckoner {
+	return newReckoner(t, clock.Real())
+}
+
+func newReckoner(t Reporter, c clock.Clock) *Reckoner {
+	return &Reckoner{t, false, &Reckoner{t, true, nil, c}, c}
+}
+
+func With(t Reporter) *Reckoner {
+	return New(t)
+}
+
+func (r *Reckoner) That(actual interface{}) *reckoning {
+	r.helper()
+	return newReckoning(r, actual)
+}
+
+func WithClock(c clock.Clock) *Reckoner {
+	return newReckoner(nil, c)
+}
+
+func (r *Reckoner) WithClock(c clock.Clock) *Reckoner {
+	var reporter Reporter
+	if r != nil {
+		reporter = r.reporter
+	}
+	out := newReckoner(reporter, c)
+	if r != nil && r.fatal {
+		return out.Require
+	}
+	return out
+}
+
+func (r *Reckoner) reporting() bool {
+	return r != nil && r.reporter != nil
+}
+
+func (r *Reckoner) helper() {
+	if r.reporting() {
+		r.reporter.Helper()
+	}
+}
+
+func (r *Reckoner) check(name string, state bool, params ...interface{}) {
+	r.helper()
+	if len(params) > 0 {
+		if p, ok := params[0].(*poller); ok {
+			r.poll(p, name, state, params[1:])
+			return
+		}
+	}
+	if !r.reporting() {
+		expectations.check(name, state, params...)
+		return
+	}
+	exp, ok := expectations.lookup(name)
+	if !ok {
+		return
+	}
+	if message := exp.evaluate(state, params); len(message) > 0 {
+		r.fail(message)
+	}
+}
+
+func (r *Reckoner) satisfy(name string, state bool, actual interface{}, params []interface{}) {
+	r.helper()
+	if _, ok := expectations.lookup(name); !ok {
+		r.fail("Unknown expectation: " + name)
+		return
+	}
+	r.check(name, state, append([]interface{}{actual}, params...)...)
+}
+
+func (r *Reckoner) fail(message string) {
+	if !r.reporting() {
+		panic(message)
+	}
+	r.reporter.Helper()
+	if r.fatal {
+		r.reporter.Fatalf("%v", message)
+	} else {
+		r.reporter.Errorf("%v", message)
+	}
+}
+
+func Soft(fn func(r *Reckoner)) {
+	failures := collect(fn)
+	if len(failures) > 0 {
+		panic(softMessage(failures))
+	}
+}
+
+func (r *Reckoner) Soft(fn func(r *Reckoner)) {
+	r.helper()
+	failures := collect(fn)
+	if !r.reporting() {
+		if len(failures) > 0 {
+			panic(softMessage(failures))
+		}
+		return
+	}
+	for _, failure := range failures {
+		r.reporter.Errorf("%v", failure)
+	}
+	if r.fatal && len(failures) > 0 {
+		r.reporter.Fatalf("%v soft expectations failed", len(failures))
+	}
+}
+
+func collect(fn func(r *Reckoner)) (failures []string) {
+	c := &collector{[]string{}}
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(softAbort); !ok {
+				panic(r)
+			}
+			failures = c.failures
+		}
+	}()
+	fn(New(c))
+	return c.failures
+}
+
+func softMessage(failures []string) string {
+	lines := []string{fmt.Sprintf("%v soft expectations failed:", len(failures))}
+	for index, failure := range failures {
+		lines = append(lines, fmt.Sprintf("%v) %v", index+1, failure))
+	}
+	return strings.Join(lines, "\n")
+}
+
+type softAbort struct{}
+
+type collector struct {
+	failures []string
+}
+
+func (c *collector) Helper() {
+}
+
+func (c *collector) Errorf(format string, args ...interface{}) {
+	c.failures = append(c.failures, fmt.Sprintf(format, args...))
+}
+
+func (c *collector) Fatalf(format string, args ...interface{}) {
+	c.Errorf(format, args...)
+	panic(softAbort{})
+}
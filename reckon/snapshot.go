@@ -0,0 +1,144 @@
+package reckon
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const snapshotExtension = ".snap"
+
+var (
+	snapshotDir    = "__snapshots__"
+	snapshotLock   sync.Mutex
+	snapshotsUsed  = map[string]bool{}
+	snapshotOwners = map[string]bool{}
+	unsafeSnapshot = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+)
+
+func updateSnapshots() bool {
+	return os.Getenv("CLOUSEAU_UPDATE") == "1"
+}
+
+func pruneSnapshots() bool {
+	return os.Getenv("CLOUSEAU_PRUNE") == "1"
+}
+
+func UseSnapshotDir(dir string) {
+	snapshotLock.Lock()
+	defer snapshotLock.Unlock()
+	snapshotDir = dir
+}
+
+func (r *reckoning) MatchesSnapshot(t Reporter, name string) {
+	t.Helper()
+	path := snapshotPath(t, name)
+	actual, err := serializeSnapshot(r.actual)
+	if err != nil {
+		t.Errorf("Snapshot %v could not be serialized: %v", name, err)
+		return
+	}
+	expected, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) || (err == nil && updateSnapshots() && string(expected) != actual) {
+		if err := writeSnapshot(path, actual); err != nil {
+			t.Errorf("Snapshot %v could not be written: %v", name, err)
+		}
+		return
+	}
+	if err != nil {
+		t.Errorf("Snapshot %v could not be read: %v", name, err)
+		return
+	}
+	if string(expected) != actual {
+		lines := lineDiff(strings.Split(actual, "\n"), strings.Split(string(expected), "\n"))
+		t.Errorf("Snapshot %v does not match (set CLOUSEAU_UPDATE=1 to update):\n\t%v", name, strings.Join(lines, "\n\t"))
+	}
+}
+
+func CheckSnapshots(t Reporter) {
+	t.Helper()
+	obsolete, err := ObsoleteSnapshots()
+	if err != nil {
+		t.Errorf("Snapshots could not be listed: %v", err)
+		return
+	}
+	for _, path := range obsolete {
+		if pruneSnapshots() {
+			if err := os.Remove(path); err != nil {
+				t.Errorf("Obsolete snapshot could not be removed: %v", err)
+			}
+		} else {
+			t.Errorf("Obsolete snapshot (set CLOUSEAU_PRUNE=1 to remove): %v", path)
+		}
+	}
+}
+
+func ObsoleteSnapshots() ([]string, error) {
+	snapshotLock.Lock()
+	defer snapshotLock.Unlock()
+	files, err := ioutil.ReadDir(snapshotDir)
+	if os.IsNotExist(err) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	obsolete := []string{}
+	for _, file := range files {
+		path := filepath.Join(snapshotDir, file.Name())
+		if !file.IsDir() && filepath.Ext(path) == snapshotExtension && !snapshotsUsed[path] && ownedSnapshot(file.Name()) {
+			obsolete = append(obsolete, path)
+		}
+	}
+	sort.Strings(obsolete)
+	return obsolete, nil
+}
+
+func ownedSnapshot(file string) bool {
+	for owner := range snapshotOwners {
+		if strings.HasPrefix(file, owner+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func snapshotPath(t Reporter, name string) string {
+	snapshotLock.Lock()
+	defer snapshotLock.Unlock()
+	name = unsafeSnapshot.ReplaceAllString(name, "_")
+	if named, ok := t.(interface{ Name() string }); ok {
+		owner := unsafeSnapshot.ReplaceAllString(named.Name(), "_")
+		snapshotOwners[owner] = true
+		name = owner + "." + name
+	}
+	path := filepath.Join(snapshotDir, name+snapshotExtension)
+	snapshotsUsed[path] = true
+	return path
+}
+
+func serializeSnapshot(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	}
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+func writeSnapshot(path, content string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, []byte(content), 0644)
+}
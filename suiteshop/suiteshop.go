@@ -14,6 +14,7 @@ type Suite struct {
 	afterAll  []dependency
 	tests     []test
 	suites    []Suite
+	verifiers []func() error
 }
 
 func newSuite(label string) *Suite {
@@ -25,6 +26,7 @@ func newSuite(label string) *Suite {
 		[]dependency{},
 		[]test{},
 		[]Suite{},
+		[]func() error{},
 	}
 }
 
@@ -44,6 +46,10 @@ func (s *Suite) AfterAll(fn func(log *Log)) {
 	s.afterAll = append(s.afterAll, dependency(fn))
 }
 
+func (s *Suite) Verify(fn func() error) {
+	s.verifiers = append(s.verifiers, fn)
+}
+
 func (s *Suite) Test(label string, fn func(log *Log)) {
 	s.tests = append(s.tests, test{label, fn})
 }
@@ -57,7 +63,7 @@ func (s *Suite) Describe(label string, core func(suite *Suite)) {
 func (s *Suite) run(log *Log, labels ...string) {
 	allLabels := append(labels, s.label)
 	label := strings.Join(allLabels, " - ")
-	test := &testRun{label, nil}
+	test := &testRun{label, nil, []string{}}
 	for _, b := range s.beforeAll {
 		b.runSafe(test, log)
 		if test.exception != nil {
@@ -74,6 +80,7 @@ func (s *Suite) run(log *Log, labels ...string) {
 			}
 			if test.exception == nil {
 				run := t.run(label, log)
+				s.verify(run, log)
 				log.append(run)
 				if run.exception != nil {
 					continue
@@ -102,6 +109,17 @@ func (s *Suite) run(log *Log, labels ...string) {
 	}
 }
 
+func (s *Suite) verify(run *testRun, log *Log) {
+	for _, fn := range s.verifiers {
+		err := fn()
+		if err != nil && run.exception == nil {
+			dependency(func(log *Log) {
+				panic(err)
+			}).runSafe(run, log)
+		}
+	}
+}
+
 func Describe(label string, core func(suite *Suite)) *Log {
 	s := newSuite(label)
 	core(s)
@@ -124,8 +142,11 @@ type test struct {
 
 func (t test) run(label string, log *Log) *testRun {
 	testName := label + " - " + t.label
-	test := &testRun{testName, nil}
+	test := &testRun{testName, nil, []string{}}
+	log.current = test
 	t.fn.runSafe(test, log)
+	log.current = nil
+	test.collectErrors()
 	return test
 }
 
@@ -170,6 +191,7 @@ func (e *exception) String() string {
 type testRun struct {
 	label     string
 	exception *exception
+	errors    []string
 }
 
 func (t *testRun) setException(message, stack string) {
@@ -185,6 +207,18 @@ func (t *testRun) setException(message, stack string) {
 	t.exception = &exception{message, steps}
 }
 
+func (t *testRun) collectErrors() {
+	if len(t.errors) == 0 {
+		return
+	}
+	message := fmt.Sprintf("%v -- %v", t.label, strings.Join(t.errors, "\n"))
+	if t.exception != nil {
+		t.exception.message = message + "\n" + t.exception.message
+	} else {
+		t.exception = &exception{message, []stackStep{}}
+	}
+}
+
 func (t *testRun) String() string {
 	e := ""
 	if t.exception != nil {
@@ -196,6 +230,7 @@ func (t *testRun) String() string {
 type Log struct {
 	tests    []testRun
 	messages []string
+	current  *testRun
 }
 
 func (l *Log) hasErrors() bool {
@@ -209,13 +244,28 @@ func (l *Log) hasErrors() bool {
 }
 
 func newLog() *Log {
-	return &Log{[]testRun{}, []string{}}
+	return &Log{[]testRun{}, []string{}, nil}
 }
 
 func (l *Log) Info(message string) {
 	l.messages = append(l.messages, message)
 }
 
+func (l *Log) Helper() {
+}
+
+func (l *Log) Errorf(format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	if l.current == nil {
+		panic(message)
+	}
+	l.current.errors = append(l.current.errors, message)
+}
+
+func (l *Log) Fatalf(format string, args ...interface{}) {
+	panic(fmt.Sprintf(format, args...))
+}
+
 func (l *Log) append(test *testRun) {
 	l.tests = append(l.tests, *test)
 }
@@ -2,6 +2,7 @@ package suiteshop_test
 
 import (
 	"."
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
@@ -82,4 +83,44 @@ func Test(t *testing.T) {
 	} else {
 		fmt.Println("Safe!")
 	}
+	verified := []string{}
+	calls := 0
+	hasErrors := suiteshop.Describe("Verify", func(suite *suiteshop.Suite) {
+		suite.Verify(func() error {
+			calls++
+			if calls == 2 {
+				return errors.New("verification failed")
+			}
+			return nil
+		})
+		suite.Test("1", func(log *suiteshop.Log) {})
+		suite.Test("2", func(log *suiteshop.Log) {})
+		suite.Test("3", func(log *suiteshop.Log) {
+			panic("body failed")
+		})
+	}).Post(func(message string) {
+		verified = append(verified, message)
+	})
+	report := strings.Join(verified, "\n")
+	if !hasErrors || calls != 3 || !strings.Contains(report, "Verify - 2 -- verification failed") || !strings.Contains(report, "Verify - 3 -- body failed") {
+		t.Fatal("verification not reported:\n" + report)
+	}
+	reported := []string{}
+	hasErrors = suiteshop.Describe("Errorf", func(suite *suiteshop.Suite) {
+		suite.Test("1", func(log *suiteshop.Log) {
+			log.Helper()
+			log.Errorf("first %v", 1)
+			log.Errorf("second %v", 2)
+		})
+		suite.Test("2", func(log *suiteshop.Log) {
+			log.Fatalf("fatal %v", 3)
+			log.Errorf("unreachable")
+		})
+	}).Post(func(message string) {
+		reported = append(reported, message)
+	})
+	report = strings.Join(reported, "\n")
+	if !hasErrors || !strings.Contains(report, "Errorf - 1 -- first 1\nsecond 2") || !strings.Contains(report, "Errorf - 2 -- fatal 3") || strings.Contains(report, "unreachable") {
+		t.Fatal("errors not reported:\n" + report)
+	}
 }